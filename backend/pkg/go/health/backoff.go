@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+type BackoffConfig struct {
+	Initial time.Duration
+	Max     time.Duration
+
+	// Timeout bounds the whole retry loop, zero retries until ctx is done.
+	Timeout time.Duration
+}
+
+// Retry calls connect until it succeeds, waiting exponentially longer with
+// jitter between attempts. It lets the service start before its dependencies
+// do instead of failing right away.
+func Retry(ctx context.Context, cfg BackoffConfig, name string, connect func(ctx context.Context) error) error {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	delay := max(cfg.Initial, time.Millisecond)
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := delay/2 + rand.N(delay/2+1)
+		ctxlogrus.Extract(ctx).Warnf("%s is unavailable (attempt %d), retrying in %s: %s", name, attempt, wait, err.Error())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s is unavailable: %w", name, err)
+		case <-time.After(wait):
+		}
+
+		delay = min(delay*2, cfg.Max)
+	}
+}
@@ -0,0 +1,4 @@
+// Package health holds the readiness checks and the startup retry shared by
+// the gateway and the etl, the probes only depend on what both services
+// already use.
+package health
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SQL pings the pool db returns, the DB method of a *gorm.DB fits it.
+func SQL(db func() (*sql.DB, error)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func Redis(client *redis.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// HTTP expects a 2xx answer from url.
+func HTTP(url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s responded with %d", url, resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+// Lag fails when nothing has happened for longer than max, last reports the
+// time of the latest event.
+func Lag(last func() time.Time, max time.Duration) func(ctx context.Context) error {
+	return func(context.Context) error {
+		if lag := time.Since(last()); lag > max {
+			return fmt.Errorf("no events for %s", lag.Truncate(time.Second))
+		}
+
+		return nil
+	}
+}
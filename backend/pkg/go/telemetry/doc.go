@@ -0,0 +1,3 @@
+// Package telemetry installs the OpenTelemetry providers the same way in every
+// service, the instruments themselves stay with the services.
+package telemetry
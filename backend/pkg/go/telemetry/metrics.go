@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	ExporterOTLP       = "otlp"
+	ExporterPrometheus = "prometheus"
+	ExporterStdout     = "stdout"
+	ExporterNone       = "none"
+)
+
+type MetricsConfig struct {
+	Exporter string
+
+	// PrometheusAddr is where the prometheus exporter serves /metrics, apart
+	// from the public API.
+	PrometheusAddr string
+	// Interval is how often the otlp and stdout exporters push.
+	Interval time.Duration
+}
+
+// InitMetrics installs the global meter provider for the configured exporter,
+// the otlp one pushes to the collector at collectorAddr. The returned function
+// flushes pending measurements and must be given a context that is still
+// alive. An exporter that cannot be set up only disables metrics, the
+// instruments keep working as no-ops.
+func InitMetrics(ctx context.Context, service, collectorAddr string, cfg MetricsConfig) func(ctx context.Context) error {
+	reader, stop, err := newReader(ctx, collectorAddr, cfg)
+	if err != nil {
+		ctxlogrus.Extract(ctx).Warnf("metrics are disabled, unable to set up %s exporter: %s", cfg.Exporter, err.Error())
+		return func(context.Context) error { return nil }
+	}
+	if reader == nil {
+		return func(context.Context) error { return nil }
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+	)
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(reader),
+	)
+
+	otel.SetMeterProvider(mp)
+
+	return func(ctx context.Context) error {
+		return errors.Join(mp.Shutdown(ctx), stop(ctx))
+	}
+}
+
+func newReader(ctx context.Context, collectorAddr string, cfg MetricsConfig) (sdkmetric.Reader, func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		exporter, err := otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpoint(collectorAddr),
+			otlpmetricgrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(cfg.Interval)), noop, nil
+
+	case ExporterStdout:
+		exporter, err := stdoutmetric.New()
+		if err != nil {
+			return nil, nil, err
+		}
+		return sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(cfg.Interval)), noop, nil
+
+	case ExporterPrometheus:
+		registry := prometheus.NewRegistry()
+		exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		listener, err := net.Listen("tcp", cfg.PrometheusAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go server.Serve(listener)
+
+		return exporter, server.Shutdown, nil
+
+	case ExporterNone:
+		return nil, noop, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown metrics exporter %q", cfg.Exporter)
+	}
+}
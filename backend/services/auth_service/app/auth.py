@@ -1,14 +1,14 @@
 from fastapi import APIRouter, Depends, HTTPException
 from sqlalchemy.ext.asyncio import AsyncSession
 from fastapi.security import OAuth2PasswordRequestForm
-from app import crud, schemas, utils
+from app import crud, keys, schemas, sessions, utils
 from app.database import SessionLocal
 from app.crud import authenticate_user
 import redis
 import os
 import base64
 from uuid import uuid4
-from fastapi import Header
+from fastapi import Header, Request
 from datetime import datetime, timedelta
 import jwt
 
@@ -32,8 +32,25 @@ async def register(user: schemas.UserCreate, db: AsyncSession = Depends(get_db))
     new_user = await crud.create_user(db, user.username, user.password)
     return {"id": new_user.id, "username": new_user.username}
 
+def _client_ip(request: Request, forwarded_for: str = None) -> str:
+    if forwarded_for:
+        return forwarded_for.split(",")[0].strip()
+    return request.client.host if request.client else ""
+
+def _access_token(user, session_id: str) -> str:
+    expire = datetime.utcnow() + timedelta(minutes=int(os.getenv("ACCESS_TOKEN_EXPIRE_MINUTES", 30)))
+    payload = {"sub": str(user.id), "username": user.username, "sid": session_id, "exp": expire}
+    kid, signing_key = keys.signing_key()
+    return jwt.encode(payload, signing_key, algorithm=keys.ALGORITHM, headers={"kid": kid})
+
 @router.post("/login", response_model=dict)
-async def login(authorization: str = Header(...), db: AsyncSession = Depends(get_db)):
+async def login(
+    request: Request,
+    authorization: str = Header(...),
+    user_agent: str = Header(""),
+    x_forwarded_for: str = Header(None),
+    db: AsyncSession = Depends(get_db),
+):
     if not authorization.startswith("Basic "):
         raise HTTPException(status_code=400, detail="Invalid Authorization header format")
     try:
@@ -46,13 +63,83 @@ async def login(authorization: str = Header(...), db: AsyncSession = Depends(get
     if not user:
         raise HTTPException(status_code=401, detail="Incorrect username or password")
 
-    expire = datetime.utcnow() + timedelta(minutes=int(os.getenv("ACCESS_TOKEN_EXPIRE_MINUTES", 30)))
-    payload = {"sub": user.id, "exp": expire}
-    token = jwt.encode(payload, os.getenv("JWT_SECRET"), algorithm=os.getenv("JWT_ALGORITHM"))
+    session_id = sessions.create(redis_client, user.id, user_agent, _client_ip(request, x_forwarded_for))
+    token = _access_token(user, session_id)
+    refresh_token = sessions.issue(redis_client, session_id, token)
 
-    redis_client.set(token, user.id, ex=int(os.getenv("ACCESS_TOKEN_EXPIRE_MINUTES", 30)) * 60)
-    
-    return {"access_token": token, "token_type": "bearer", "user_id": user.id}
+    return {"access_token": token, "refresh_token": refresh_token, "token_type": "bearer", "user_id": user.id}
+
+@router.post("/refresh", response_model=dict)
+async def refresh(
+    body: schemas.RefreshRequest,
+    request: Request,
+    user_agent: str = Header(""),
+    x_forwarded_for: str = Header(None),
+    db: AsyncSession = Depends(get_db),
+):
+    session, reused = sessions.resolve_refresh(redis_client, body.refresh_token)
+    if session is None:
+        raise HTTPException(status_code=401, detail="Invalid or expired refresh token")
+
+    if reused:
+        # A rotated refresh token was presented again: somebody holds a copy,
+        # so the whole session is considered compromised. The gateway verifies
+        # access tokens on its own and learns about the revocation from the
+        # header.
+        sessions.revoke(redis_client, session["id"])
+        raise HTTPException(
+            status_code=401,
+            detail="Refresh token reuse detected",
+            headers={"X-Revoked-Session": session["id"]},
+        )
+
+    user = await crud.get_user_by_id(db, int(session["user_id"]))
+    if not user:
+        sessions.revoke(redis_client, session["id"])
+        raise HTTPException(
+            status_code=401,
+            detail="Invalid or expired refresh token",
+            headers={"X-Revoked-Session": session["id"]},
+        )
+
+    redis_client.hset(f"session:{session['id']}", mapping={
+        "device": user_agent or session.get("device", ""),
+        "ip": _client_ip(request, x_forwarded_for),
+    })
+    token = _access_token(user, session["id"])
+    refresh_token = sessions.issue(redis_client, session["id"], token)
+
+    return {"access_token": token, "refresh_token": refresh_token, "token_type": "bearer", "user_id": user.id}
+
+@router.post("/external-login", response_model=dict)
+async def external_login(
+    body: schemas.ExternalLoginRequest,
+    request: Request,
+    user_agent: str = Header(""),
+    x_forwarded_for: str = Header(None),
+    db: AsyncSession = Depends(get_db),
+):
+    identity = await crud.get_external_identity(db, body.provider, body.subject)
+
+    if body.link_user_id:
+        if identity and identity.user_id != body.link_user_id:
+            raise HTTPException(status_code=409, detail="Identity is linked to another account")
+        user = await crud.get_user_by_id(db, body.link_user_id)
+        if not user:
+            raise HTTPException(status_code=401, detail="Unknown user")
+        if not identity:
+            await crud.link_external_identity(db, user.id, body.provider, body.subject, body.email)
+    elif identity:
+        user = await crud.get_user_by_id(db, identity.user_id)
+    else:
+        user = await crud.create_external_user(db, body.username or body.email.split("@")[0])
+        await crud.link_external_identity(db, user.id, body.provider, body.subject, body.email)
+
+    session_id = sessions.create(redis_client, user.id, user_agent, _client_ip(request, x_forwarded_for))
+    token = _access_token(user, session_id)
+    refresh_token = sessions.issue(redis_client, session_id, token)
+
+    return {"access_token": token, "refresh_token": refresh_token, "token_type": "bearer", "user_id": user.id}
 
 
 @router.get("/authorize", response_model=dict)
@@ -61,7 +148,10 @@ async def authorize(token: str, db: AsyncSession = Depends(get_db)):
         raise HTTPException(status_code=401, detail="Invalid or expired token")
     
     try:
-        payload = jwt.decode(token, os.getenv("JWT_SECRET"), algorithms=[os.getenv("JWT_ALGORITHM")])
+        public_key = keys.public_key(jwt.get_unverified_header(token).get("kid", ""))
+        if public_key is None:
+            raise HTTPException(status_code=401, detail="Invalid token")
+        payload = jwt.decode(token, public_key, algorithms=[keys.ALGORITHM])
         user_id = payload.get("sub")
         if not user_id:
             raise HTTPException(status_code=401, detail="Invalid token")
@@ -70,17 +160,64 @@ async def authorize(token: str, db: AsyncSession = Depends(get_db)):
     except jwt.PyJWTError:
         raise HTTPException(status_code=401, detail="Invalid token")
     
-    return {"user_id": user_id}
+    session_id = payload.get("sid", "")
+    if session_id:
+        sessions.touch(redis_client, session_id)
+
+    return {"user_id": int(user_id), "username": payload.get("username", ""), "session_id": session_id}
+
+@router.get("/.well-known/jwks.json", response_model=dict)
+async def jwks():
+    return keys.jwks()
 
 @router.get("/get-username/{user_id}", response_model=dict)
 async def get_username(user_id: int, db: AsyncSession = Depends(get_db)):
     user = await crud.get_user_by_id(db, user_id)
     if not user:
         return {"username": ""}
-    return {"username": user.username}
+    return {
+        "username": user.username,
+        "updated_at": user.updated_at.isoformat() if user.updated_at else None,
+    }
 
 @router.post("/logout")
 async def logout(token: str):
-    if redis_client.delete(token):
-        return {"detail": "Successfully logged out"}
-    raise HTTPException(status_code=400, detail="Invalid token")
\ No newline at end of file
+    session_id = redis_client.get(token)
+    if not session_id:
+        raise HTTPException(status_code=400, detail="Invalid token")
+
+    if not sessions.revoke(redis_client, session_id):
+        redis_client.delete(token)
+    return {"detail": "Successfully logged out"}
+
+@router.get("/sessions", response_model=list)
+async def list_sessions(user_id: int):
+    return sessions.list_for_user(redis_client, user_id)
+
+@router.delete("/sessions")
+async def revoke_sessions(user_id: int):
+    sessions.revoke_all(redis_client, user_id)
+    return {"detail": "Sessions revoked"}
+
+@router.post("/sessions/{session_id}/seen")
+async def session_seen(session_id: str):
+    if sessions.get(redis_client, session_id) is None:
+        raise HTTPException(status_code=404, detail="Session not found")
+
+    sessions.touch(redis_client, session_id)
+    return {"detail": "Session touched"}
+
+@router.delete("/sessions/{session_id}")
+async def revoke_session(session_id: str, user_id: int):
+    session = sessions.get(redis_client, session_id)
+    if session is None or int(session["user_id"]) != user_id:
+        raise HTTPException(status_code=404, detail="Session not found")
+
+    sessions.revoke(redis_client, session_id)
+    return {"detail": "Session revoked"}
+@router.delete("/users/{user_id}")
+async def delete_user(user_id: int, db: AsyncSession = Depends(get_db)):
+    sessions.revoke_all(redis_client, user_id)
+    if not await crud.delete_user(db, user_id):
+        raise HTTPException(status_code=404, detail="User not found")
+    return {"detail": "User deleted"}
@@ -1,6 +1,7 @@
 from sqlalchemy.future import select
 from sqlalchemy.ext.asyncio import AsyncSession
-from app.models import User
+import secrets
+from app.models import ExternalIdentity, User
 from app import utils
 from app.utils import verify_password
 
@@ -25,4 +26,34 @@ async def authenticate_user(db: AsyncSession, username: str, password: str):
     user = result.scalars().first()
     if user and verify_password(password, user.hashed_password):
         return user
-    return None
\ No newline at end of file
+    return None
+
+async def get_external_identity(db: AsyncSession, provider: str, subject: str):
+    result = await db.execute(
+        select(ExternalIdentity).where(ExternalIdentity.provider == provider, ExternalIdentity.subject == subject)
+    )
+    return result.scalars().first()
+
+async def link_external_identity(db: AsyncSession, user_id: int, provider: str, subject: str, email: str):
+    identity = ExternalIdentity(user_id=user_id, provider=provider, subject=subject, email=email)
+    db.add(identity)
+    await db.commit()
+    return identity
+
+async def create_external_user(db: AsyncSession, username: str):
+    # External accounts get a random password nobody knows, so they can only
+    # sign in through their identity provider.
+    candidate = username or "user"
+    suffix = 0
+    while await get_user_by_username(db, candidate):
+        suffix += 1
+        candidate = f"{username or 'user'}{suffix}"
+    return await create_user(db, candidate, secrets.token_urlsafe(32))
+
+async def delete_user(db: AsyncSession, user_id: int) -> bool:
+    user = await get_user_by_id(db, user_id)
+    if not user:
+        return False
+    await db.delete(user)
+    await db.commit()
+    return True
@@ -0,0 +1,85 @@
+import base64
+import os
+
+from cryptography.hazmat.primitives import serialization
+from cryptography.hazmat.primitives.asymmetric import rsa
+
+ALGORITHM = "RS256"
+
+
+def _b64url_uint(value: int) -> str:
+    raw = value.to_bytes((value.bit_length() + 7) // 8, "big")
+    return base64.urlsafe_b64encode(raw).rstrip(b"=").decode("ascii")
+
+
+def _load_keys():
+    # Every *.pem file in JWT_KEYS_DIR is published in the JWKS; the most
+    # recently modified one signs new tokens. Rotating is a matter of dropping
+    # a new key next to the old ones and removing the old file once every
+    # token it signed has expired.
+    keys_dir = os.getenv("JWT_KEYS_DIR", "")
+    if not keys_dir or not os.path.isdir(keys_dir):
+        raise RuntimeError("JWT_KEYS_DIR must point to a directory with signing keys")
+
+    if not _pem_files(keys_dir):
+        _generate_key(keys_dir)
+
+    keys = []
+    for path in sorted(_pem_files(keys_dir), key=os.path.getmtime):
+        with open(path, "rb") as file:
+            private_key = serialization.load_pem_private_key(file.read(), password=None)
+        kid = os.path.splitext(os.path.basename(path))[0]
+        keys.append((kid, private_key))
+
+    return keys
+
+
+def _pem_files(keys_dir: str) -> list:
+    return [os.path.join(keys_dir, f) for f in os.listdir(keys_dir) if f.endswith(".pem")]
+
+
+def _generate_key(keys_dir: str):
+    # The first start on an empty directory writes a key there, so restarts
+    # and replicas sharing the directory keep signing with the same one. The
+    # exclusive create lets only one of several starting replicas write it.
+    private_key = rsa.generate_private_key(public_exponent=65537, key_size=2048)
+    pem = private_key.private_bytes(
+        encoding=serialization.Encoding.PEM,
+        format=serialization.PrivateFormat.PKCS8,
+        encryption_algorithm=serialization.NoEncryption(),
+    )
+
+    try:
+        fd = os.open(os.path.join(keys_dir, "initial.pem"), os.O_WRONLY | os.O_CREAT | os.O_EXCL, 0o600)
+    except FileExistsError:
+        return
+
+    with os.fdopen(fd, "wb") as file:
+        file.write(pem)
+
+
+_keys = _load_keys()
+_public_keys = {kid: key.public_key() for kid, key in _keys}
+
+
+def signing_key():
+    return _keys[-1]
+
+
+def public_key(kid: str):
+    return _public_keys.get(kid)
+
+
+def jwks() -> dict:
+    result = []
+    for kid, key in _public_keys.items():
+        numbers = key.public_numbers()
+        result.append({
+            "kid": kid,
+            "kty": "RSA",
+            "alg": ALGORITHM,
+            "use": "sig",
+            "n": _b64url_uint(numbers.n),
+            "e": _b64url_uint(numbers.e),
+        })
+    return {"keys": result}
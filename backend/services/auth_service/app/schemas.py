@@ -14,4 +14,15 @@ class User(UserBase):
 
 class Token(BaseModel):
     access_token: str
-    token_type: str
\ No newline at end of file
+    token_type: str
+
+class RefreshRequest(BaseModel):
+    refresh_token: str
+
+
+class ExternalLoginRequest(BaseModel):
+    provider: str
+    subject: str
+    email: str = ""
+    username: str = ""
+    link_user_id: int = 0
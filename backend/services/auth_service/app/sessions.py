@@ -0,0 +1,143 @@
+import hashlib
+import os
+import secrets
+from datetime import datetime
+from uuid import uuid4
+
+ACCESS_TOKEN_TTL = int(os.getenv("ACCESS_TOKEN_EXPIRE_MINUTES", 30)) * 60
+REFRESH_TOKEN_TTL = int(os.getenv("REFRESH_TOKEN_EXPIRE_DAYS", 30)) * 24 * 60 * 60
+
+# Claims a refresh token: a live one is moved to the used keys in the same
+# step, so of two requests presenting it concurrently only one gets the
+# session and the other one is seen as reuse.
+_CLAIM_REFRESH = """
+local session_id = redis.call('GET', KEYS[1])
+if session_id then
+    redis.call('DEL', KEYS[1])
+    redis.call('SET', KEYS[2], session_id, 'EX', ARGV[1])
+    return {session_id, 0}
+end
+
+session_id = redis.call('GET', KEYS[2])
+if session_id then
+    return {session_id, 1}
+end
+
+return false
+"""
+
+
+def _digest(refresh_token: str) -> str:
+    return hashlib.sha256(refresh_token.encode("utf-8")).hexdigest()
+
+
+def _now() -> str:
+    return datetime.utcnow().isoformat() + "Z"
+
+
+def create(redis_client, user_id: int, device: str, ip: str) -> str:
+    session_id = uuid4().hex
+    now = _now()
+    redis_client.hset(f"session:{session_id}", mapping={
+        "user_id": user_id,
+        "device": device or "",
+        "ip": ip or "",
+        "created_at": now,
+        "last_seen": now,
+    })
+    redis_client.expire(f"session:{session_id}", REFRESH_TOKEN_TTL)
+    redis_client.sadd(f"user_sessions:{user_id}", session_id)
+    return session_id
+
+
+def issue(redis_client, session_id: str, access_token: str) -> str:
+    """Binds a fresh access token and refresh token to the session.
+
+    The previous refresh token is moved to the used set of the session, so
+    presenting it again is detected as reuse.
+    """
+    key = f"session:{session_id}"
+    previous = redis_client.hget(key, "refresh")
+    if previous:
+        redis_client.delete(f"refresh:{previous}")
+        redis_client.set(f"refresh_used:{previous}", session_id, ex=REFRESH_TOKEN_TTL)
+
+    previous_access = redis_client.hget(key, "access")
+    if previous_access:
+        redis_client.delete(previous_access)
+
+    refresh_token = secrets.token_urlsafe(48)
+    digest = _digest(refresh_token)
+
+    redis_client.set(f"refresh:{digest}", session_id, ex=REFRESH_TOKEN_TTL)
+    redis_client.set(access_token, session_id, ex=ACCESS_TOKEN_TTL)
+    redis_client.hset(key, mapping={"refresh": digest, "access": access_token, "last_seen": _now()})
+    redis_client.expire(key, REFRESH_TOKEN_TTL)
+    return refresh_token
+
+
+def resolve_refresh(redis_client, refresh_token: str):
+    """Claims the presented refresh token and returns (session, reused).
+
+    A token is only claimed once, new tokens must be issued only when it was
+    not reused.
+    """
+    digest = _digest(refresh_token)
+
+    claimed = redis_client.eval(
+        _CLAIM_REFRESH, 2, f"refresh:{digest}", f"refresh_used:{digest}", REFRESH_TOKEN_TTL,
+    )
+    if not claimed:
+        return None, False
+
+    session_id, reused = claimed
+    return get(redis_client, session_id), bool(reused)
+
+
+def get(redis_client, session_id: str):
+    data = redis_client.hgetall(f"session:{session_id}")
+    if not data:
+        return None
+    data["id"] = session_id
+    return data
+
+
+def touch(redis_client, session_id: str):
+    redis_client.hset(f"session:{session_id}", "last_seen", _now())
+
+
+def list_for_user(redis_client, user_id: int):
+    result = []
+    for session_id in redis_client.smembers(f"user_sessions:{user_id}"):
+        session = get(redis_client, session_id)
+        if session is None:
+            redis_client.srem(f"user_sessions:{user_id}", session_id)
+            continue
+        result.append({
+            "id": session_id,
+            "device": session.get("device", ""),
+            "ip": session.get("ip", ""),
+            "created_at": session.get("created_at"),
+            "last_seen": session.get("last_seen"),
+        })
+    return result
+
+
+def revoke(redis_client, session_id: str) -> bool:
+    session = get(redis_client, session_id)
+    if session is None:
+        return False
+
+    if session.get("access"):
+        redis_client.delete(session["access"])
+    if session.get("refresh"):
+        redis_client.delete(f"refresh:{session['refresh']}")
+    redis_client.delete(f"session:{session_id}")
+    redis_client.srem(f"user_sessions:{session['user_id']}", session_id)
+    return True
+
+
+def revoke_all(redis_client, user_id: int):
+    for session_id in redis_client.smembers(f"user_sessions:{user_id}"):
+        revoke(redis_client, session_id)
+    redis_client.delete(f"user_sessions:{user_id}")
@@ -44,24 +44,28 @@
 #     import asyncio
 #     logger.info("Running migrate()...")
 #     asyncio.run(migrate())
-from sqlalchemy import inspect
+from sqlalchemy import inspect, text
 from app.database import Base, engine
-from app.models import User
+from app.models import ExternalIdentity, User
 
 async def migrate():
     async with engine.begin() as conn:
-        def sync_inspect(connection):
+        def sync_missing_tables(connection):
             inspector = inspect(connection)
-            return inspector.has_table("users")
-        has_users_table = await conn.run_sync(sync_inspect)
+            return [name for name in Base.metadata.tables if not inspector.has_table(name)]
+        missing_tables = await conn.run_sync(sync_missing_tables)
         
-        if not has_users_table:
-            print("Creating tables...")
+        if missing_tables:
+            print(f"Creating tables {', '.join(missing_tables)}...")
             await conn.run_sync(Base.metadata.create_all)
             print("Tables created")
         else:
             print("Tables already exist")
 
+        await conn.execute(text(
+            "ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()"
+        ))
+
 if __name__ == "__main__":
     import asyncio
     asyncio.run(migrate())
\ No newline at end of file
@@ -2,47 +2,107 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/health"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/application/pipeline"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/config"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/clients/tmdb"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/repositories/movies"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
-	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
 )
 
 func main() {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	ctx = ctxlogrus.ToContext(ctx, logger.WithFields(logrus.Fields{"service": "etl"}))
+
+	if err := run(ctx, logger); err != nil {
+		logger.Error(err)
+		os.Exit(1)
+	}
+
+	logger.Info("graceful shutdown")
+}
+
+func run(ctx context.Context, logger *logrus.Logger) error {
 	cfg := config.MustLoad()
 	logger.Info("config loaded")
 
+	stopTracing := telemetry.MustInitTracing(ctx, "etl", cfg.Collector.Addr)
+	stopMetrics := telemetry.InitMetrics(ctx, "etl", cfg.Collector.Addr, cfg.Metrics)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := errors.Join(stopTracing(ctx), stopMetrics(ctx)); err != nil {
+			logger.Warnf("unable to flush telemetry: %s", err.Error())
+		}
+	}()
+
 	tmdbClient := tmdb.NewHTTPClient(cfg.TMDB)
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Movies.Addr, Password: "", DB: 0})
-	if err := redisClient.Echo(context.Background(), nil).Err(); err != nil {
-		logger.Fatal(err)
+	defer redisClient.Close()
+
+	err := health.Retry(ctx, cfg.Startup, "redis", func(ctx context.Context) error {
+		return redisClient.Echo(ctx, nil).Err()
+	})
+	if err != nil {
+		return err
 	}
 
 	logger.Info("redis connection established")
 
 	moviesRepo := movies.NewRedisPublisher(redisClient, cfg.Movies)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	pipeline := pipeline.New(cfg.Pipeline, moviesRepo, tmdbClient)
 
-	ctx = ctxlogrus.ToContext(ctx, logger.WithFields(logrus.Fields{"service": "etl"}))
+	checker := health.New(cfg.Health.Timeout)
+	checker.Add(health.Check{Name: "redis", Critical: true, Probe: health.Redis(redisClient)})
+	checker.Add(health.Check{Name: "pipeline", Probe: health.Lag(pipeline.LastBatch, cfg.Health.MaxPipelineLag)})
 
-	pipeline := pipeline.New(cfg.Pipeline, moviesRepo, tmdbClient)
+	stopHealth, err := serveHealth(cfg.Health.Addr, checker)
+	if err != nil {
+		return err
+	}
+	defer stopHealth()
 
 	logger.Info("starting to download")
 
-	pipeline.Start(ctx)
+	return pipeline.Start(ctx)
+}
 
-	signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+// serveHealth binds the port up front so a taken address fails startup
+// instead of leaving the service running without probes.
+func serveHealth(addr string, checker *health.Checker) (func(), error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start health server: %w", err)
+	}
 
-	logger.Info("graceful shutdown")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", checker.Liveness)
+	mux.HandleFunc("GET /readyz", checker.Readiness)
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go server.Serve(listener)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}, nil
 }
@@ -11,4 +11,7 @@ type Config struct {
 
 	// The duration to wait before fetching the next batch of movies.
 	ExtractTickrate time.Duration
+
+	// How long a batch interrupted by shutdown may keep fetching.
+	DrainTimeout time.Duration
 }
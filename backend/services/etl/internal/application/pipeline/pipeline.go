@@ -2,10 +2,19 @@ package pipeline
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
+	"time"
 
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/clients"
+	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/entities"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/repositories"
+	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/metrics"
 	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Pipeline struct {
@@ -13,27 +22,119 @@ type Pipeline struct {
 
 	movies repositories.Movies
 	tmdb   clients.TMDB
+	tracer trace.Tracer
+
+	lastBatch atomic.Int64
 }
 
 func New(config Config, movies repositories.Movies, tmdb clients.TMDB) *Pipeline {
-	return &Pipeline{
+	p := &Pipeline{
 		cfg:    config,
 		movies: movies,
 		tmdb:   tmdb,
+		tracer: otel.Tracer("etl.pipeline"),
 	}
+	p.lastBatch.Store(time.Now().UnixNano())
+
+	return p
+}
+
+// LastBatch reports when the latest batch was published, or when the pipeline
+// was created if none has been yet.
+func (p *Pipeline) LastBatch() time.Time {
+	return time.Unix(0, p.lastBatch.Load())
 }
 
+// Start fetches and publishes a batch every tick until ctx is cancelled. A
+// cancellation lets the current batch fetch its remaining ids, for at most
+// DrainTimeout, and publish before Start returns.
 func (p *Pipeline) Start(ctx context.Context) error {
-	moviesCh := p.tmdb.FetchMovies(ctx, int64(p.cfg.StartID), p.cfg.BatchSize, p.cfg.ExtractTickrate)
+	ticker := time.NewTicker(p.cfg.ExtractTickrate)
+	defer ticker.Stop()
+
+	startID := int64(p.cfg.StartID)
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case batch := <-moviesCh:
-			ctxlogrus.Extract(ctx).Info("batch of movies fetched successfully")
-			if err := p.movies.InsertMovies(ctx, batch); err != nil {
-				ctxlogrus.Extract(ctx).Errorf("unable to insert movies to repo: %s", err.Error())
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				return nil
 			}
+
+			p.processBatch(ctx, startID, p.cfg.BatchSize)
+			startID += int64(p.cfg.BatchSize)
+		}
+	}
+}
+
+func (p *Pipeline) processBatch(ctx context.Context, startID int64, size int) {
+	ctx, span := p.tracer.Start(ctx, "pipeline.Batch", trace.WithAttributes(
+		attribute.Int64("batch.start_id", startID),
+		attribute.Int("batch.size", size),
+	))
+	defer span.End()
+
+	fetchCtx, cancel := drainContext(ctx, p.cfg.DrainTimeout)
+	defer cancel()
+
+	movies := make([]entities.Movie, 0, size)
+	missing, failed := 0, 0
+	for id := startID; id < startID+int64(size) && fetchCtx.Err() == nil; id++ {
+		movie, err := p.tmdb.FetchMovie(fetchCtx, id)
+		switch {
+		case err == nil:
+			movies = append(movies, movie)
+		case errors.Is(err, clients.ErrMovieNotFound):
+			missing++
+		case fetchCtx.Err() != nil:
+			ctxlogrus.Extract(ctx).Warnf("drain timeout ran out, batch stopped before id %d", id)
+		default:
+			ctxlogrus.Extract(ctx).Warn(err.Error())
+			failed++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("batch.fetched", len(movies)),
+		attribute.Int("batch.missing", missing),
+		attribute.Int("batch.failed", failed),
+	)
+	metrics.RecordMovies(ctx, metrics.MoviesFetched, len(movies))
+	metrics.RecordMovies(ctx, metrics.MoviesMissing, missing)
+	metrics.RecordMovies(ctx, metrics.MoviesFailed, failed)
+
+	if len(movies) == 0 {
+		return
+	}
+
+	ctxlogrus.Extract(ctx).Infof("batch of %d movies fetched successfully", len(movies))
+	if err := p.movies.InsertMovies(context.WithoutCancel(ctx), movies); err != nil {
+		ctxlogrus.Extract(ctx).Errorf("unable to insert movies to repo: %s", err.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	metrics.RecordMovies(ctx, metrics.MoviesPublished, len(movies))
+	p.lastBatch.Store(time.Now().UnixNano())
+}
+
+// drainContext outlives ctx by at most timeout: it carries ctx's values but is
+// only cancelled timeout after ctx is done, so work in flight can finish.
+func drainContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	drain, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	var timer atomic.Pointer[time.Timer]
+	stop := context.AfterFunc(ctx, func() {
+		timer.Store(time.AfterFunc(timeout, cancel))
+	})
+
+	return drain, func() {
+		stop()
+		if t := timer.Load(); t != nil {
+			t.Stop()
 		}
+		cancel()
 	}
 }
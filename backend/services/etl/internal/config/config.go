@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/health"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/application/pipeline"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/clients/tmdb"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/repositories/movies"
@@ -12,30 +14,64 @@ import (
 )
 
 type Config struct {
-	Pipeline pipeline.Config
-	Movies   movies.Config
-	TMDB     tmdb.Config
+	Pipeline  pipeline.Config
+	Movies    movies.Config
+	TMDB      tmdb.Config
+	Health    HealthConfig
+	Collector CollectorConfig
+	Metrics   telemetry.MetricsConfig
+	Startup   health.BackoffConfig
+}
+
+type CollectorConfig struct {
+	// Addr receives the traces and, with the otlp exporter, the metrics.
+	Addr string
+}
+
+type HealthConfig struct {
+	Addr           string
+	Timeout        time.Duration
+	MaxPipelineLag time.Duration
 }
 
 func MustLoad() Config {
 	godotenv.Load()
 	return Config{
 		Pipeline: pipeline.Config{
-			BatchSize: intOrDefault("DOWNLOAD_BATCH_SIZE", 10),
-			StartID: intOrDefault("DOWNLOAD_START_ID", 1),
+			BatchSize:       intOrDefault("DOWNLOAD_BATCH_SIZE", 10),
+			StartID:         intOrDefault("DOWNLOAD_START_ID", 1),
 			ExtractTickrate: timeOrDefault("DOWNLOAD_TICKRATE", time.Minute),
+			DrainTimeout:    timeOrDefault("DOWNLOAD_DRAIN_TIMEOUT", 30*time.Second),
 		},
 		Movies: movies.Config{
 			RedisPublisherConfig: movies.RedisPublisherConfig{
-				Addr: stringOrDefault("REDIS_ADDR", ""),
-				Timeout: timeOrDefault("REDIS_TIMEOUT", 5 * time.Second),
+				Addr:           stringOrDefault("REDIS_ADDR", ""),
+				Timeout:        timeOrDefault("REDIS_TIMEOUT", 5*time.Second),
 				PublishChannel: stringOrDefault("REDIS_PUBLISH_CHANNEL", "movies"),
 			},
 		},
 		TMDB: tmdb.Config{
-			Bearer: stringOrDefault("TMDB_BEARER_TOKEN", ""),
-			RequestTimeout: timeOrDefault("TMDB_REQUEST_TIMEOUT", 5 * time.Second),
-			Host: stringOrDefault("TMDB_HOST", "api.themoviedb.org"),
+			Bearer:         stringOrDefault("TMDB_BEARER_TOKEN", ""),
+			RequestTimeout: timeOrDefault("TMDB_REQUEST_TIMEOUT", 5*time.Second),
+			Host:           stringOrDefault("TMDB_HOST", "api.themoviedb.org"),
+		},
+		Health: HealthConfig{
+			Addr:           stringOrDefault("HEALTH_ADDR", ":8081"),
+			Timeout:        timeOrDefault("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			MaxPipelineLag: timeOrDefault("HEALTH_MAX_PIPELINE_LAG", 10*time.Minute),
+		},
+		Collector: CollectorConfig{
+			Addr: stringOrDefault("COLLECTOR_ADDR", ""),
+		},
+		Metrics: telemetry.MetricsConfig{
+			Exporter:       stringOrDefault("METRICS_EXPORTER", telemetry.ExporterOTLP),
+			PrometheusAddr: stringOrDefault("METRICS_PROMETHEUS_ADDR", ":9464"),
+			Interval:       timeOrDefault("METRICS_INTERVAL", time.Minute),
+		},
+		Startup: health.BackoffConfig{
+			Initial: timeOrDefault("STARTUP_BACKOFF_INITIAL", 500*time.Millisecond),
+			Max:     timeOrDefault("STARTUP_BACKOFF_MAX", 10*time.Second),
+			Timeout: timeOrDefault("STARTUP_TIMEOUT", 2*time.Minute),
 		},
 	}
 }
@@ -59,7 +95,7 @@ func timeOrDefault(envName string, defaultValue time.Duration) time.Duration {
 	if !ok {
 		return defaultValue
 	}
-	
+
 	value, err := time.ParseDuration(raw)
 	if err != nil {
 		return defaultValue
@@ -75,4 +111,4 @@ func stringOrDefault(envName, defaultValue string) string {
 	}
 
 	return value
-}
\ No newline at end of file
+}
@@ -2,14 +2,15 @@ package clients
 
 import (
 	"context"
-	"time"
+	"errors"
 
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/entities"
 )
 
+// ErrMovieNotFound is returned for ids TMDB has no movie for, the id space has
+// gaps so it is expected and not a failure.
+var ErrMovieNotFound = errors.New("movie not found")
+
 type TMDB interface {
-	// FetchMovies fetches movies from TMDB based on the provided tickrate
-	// starting from the given startID and with a specified size.
-	// It returns a channel that emits slices of Movie entities.
-	FetchMovies(ctx context.Context, startID int64, size int, tickrate time.Duration) <-chan []entities.Movie
+	FetchMovie(ctx context.Context, id int64) (entities.Movie, error)
 }
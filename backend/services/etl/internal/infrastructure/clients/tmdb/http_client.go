@@ -10,11 +10,16 @@ import (
 
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/clients"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/entities"
-	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type httpClient struct {
 	client *http.Client
+	tracer trace.Tracer
 
 	cfg Config
 }
@@ -22,71 +27,70 @@ type httpClient struct {
 func NewHTTPClient(cfg Config) clients.TMDB {
 	return &httpClient{
 		client: http.DefaultClient,
+		tracer: otel.Tracer("etl.tmdb"),
 		cfg:    cfg,
 	}
 }
 
-func (h *httpClient) FetchMovies(ctx context.Context, startID int64, size int, tickrate time.Duration) <-chan []entities.Movie {
-	moviesChan := make(chan []entities.Movie)
-
-	go func() {
-		defer close(moviesChan)
-
-		ticker := time.NewTicker(tickrate)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-
-			case <-ticker.C:
-				movies := make([]entities.Movie, 0, size)
-				for i := startID; i < startID+int64(size); i++ {
-					url := fmt.Sprintf("https://%s/3/movie/%d?append_to_response=credits&language=en-US", h.cfg.Host, i)
-
-					reqCtx, cancel := context.WithTimeout(ctx, h.cfg.RequestTimeout)
-					defer cancel()
-
-					req, err := http.NewRequestWithContext(reqCtx, "GET", url, nil)
-					if err != nil {
-						ctxlogrus.Extract(ctx).Warnf("error creating request: %v\n", err)
-						continue
-					}
-
-					req.Header.Add("accept", "application/json")
-					req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.cfg.Bearer))
-
-					res, err := h.client.Do(req)
-					if err != nil {
-						ctxlogrus.Extract(ctx).Warnf("error making request: %v\n", err)
-						continue
-					}
-					defer res.Body.Close()
-
-					if res.StatusCode != http.StatusOK {
-						ctxlogrus.Extract(ctx).Warnf("Received non-200 response: %d\n", res.StatusCode)
-						body, _ := io.ReadAll(res.Body)
-						ctxlogrus.Extract(ctx).Warnf("Response body: %s\n", body)
-						continue
-					}
-
-					body, _ := io.ReadAll(res.Body)
-
-					var movie entities.Movie
-					if err := json.Unmarshal(body, &movie); err != nil {
-						ctxlogrus.Extract(ctx).Warnf("Error unmarshalling response: %v\n", err)
-						continue
-					}
-
-					movies = append(movies, movie)
-				}
-
-				moviesChan <- movies
-				startID += int64(size)
-			}
-		}
-	}()
-
-	return moviesChan
+func (h *httpClient) FetchMovie(ctx context.Context, id int64) (entities.Movie, error) {
+	ctx, span := h.tracer.Start(ctx, "tmdb.FetchMovie", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(attribute.Int64("tmdb.movie_id", id))
+
+	start := time.Now()
+	movie, status, err := h.fetchMovie(ctx, id)
+	metrics.RecordTMDBRequest(ctx, time.Since(start), status)
+
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if err != nil && status != http.StatusNotFound {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return movie, err
+}
+
+// fetchMovie reports the response status alongside the result, zero when no
+// response was received.
+func (h *httpClient) fetchMovie(ctx context.Context, id int64) (entities.Movie, int, error) {
+	url := fmt.Sprintf("https://%s/3/movie/%d?append_to_response=credits&language=en-US", h.cfg.Host, id)
+
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return entities.Movie{}, 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Add("accept", "application/json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.cfg.Bearer))
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return entities.Movie{}, 0, fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return entities.Movie{}, res.StatusCode, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return entities.Movie{}, res.StatusCode, fmt.Errorf("%w: %d", clients.ErrMovieNotFound, id)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return entities.Movie{}, res.StatusCode, fmt.Errorf("received non-200 response: %d, body: %s", res.StatusCode, body)
+	}
+
+	var movie entities.Movie
+	if err := json.Unmarshal(body, &movie); err != nil {
+		return entities.Movie{}, res.StatusCode, fmt.Errorf("error unmarshalling response: %w", err)
+	}
+
+	return movie, res.StatusCode, nil
 }
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	MoviesFetched   = "fetched"
+	MoviesMissing   = "missing"
+	MoviesFailed    = "failed"
+	MoviesPublished = "published"
+)
+
+var (
+	moviesCounter        metric.Int64Counter
+	tmdbLatencyHistogram metric.Float64Histogram
+)
+
+// Instruments are created against the global provider, which forwards them to
+// the one telemetry.InitMetrics installs.
+func init() {
+	mustCreateInstruments(otel.Meter("etl.metrics"))
+}
+
+func mustCreateInstruments(meter metric.Meter) {
+	var err error
+
+	moviesCounter, err = meter.Int64Counter("etl_movies",
+		metric.WithDescription("Movies by outcome: fetched, missing on TMDB, failed to fetch or published"),
+		metric.WithUnit("{movie}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	tmdbLatencyHistogram, err = meter.Float64Histogram("tmdb_request_duration_ms",
+		metric.WithDescription("TMDB response time by status"),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(
+			25, 50, 100, 250, 500,
+			1000, 2500, 5000, 10000,
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func RecordMovies(ctx context.Context, outcome string, count int) {
+	if count == 0 {
+		return
+	}
+
+	moviesCounter.Add(ctx, int64(count), metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+// RecordTMDBRequest takes a zero status for requests that got no response.
+func RecordTMDBRequest(ctx context.Context, duration time.Duration, status int) {
+	class := "error"
+	if status != 0 {
+		class = fmt.Sprintf("%dxx", status/100)
+	}
+
+	tmdbLatencyHistogram.Record(ctx, float64(duration.Microseconds())/1000,
+		metric.WithAttributes(attribute.String("status_class", class)),
+	)
+}
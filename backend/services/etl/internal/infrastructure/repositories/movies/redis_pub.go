@@ -6,9 +6,17 @@ import (
 
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/entities"
 	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/domain/repositories"
+	"github.com/allnightmarel0Ng/cinema/backend/services/etl/internal/infrastructure/tracing"
 	"github.com/redis/go-redis/v9"
 )
 
+// message carries the trace context next to the batch so the gateway stores
+// it as part of the same trace.
+type message struct {
+	Trace  map[string]string `json:"trace"`
+	Movies []entities.Movie  `json:"movies"`
+}
+
 type redisPublisher struct {
 	client *redis.Client
 	cfg    Config
@@ -22,7 +30,7 @@ func (rp *redisPublisher) InsertMovies(ctx context.Context, movies []entities.Mo
 	ctx, cancel := context.WithTimeout(ctx, rp.cfg.Timeout.Abs())
 	defer cancel()
 
-	raw, err := json.Marshal(movies)
+	raw, err := json.Marshal(message{Trace: tracing.Inject(ctx), Movies: movies})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var ErrUnauthorized = errors.New("unauthorized")
+
+// TokenStore holds the token pair of a session. It is safe for concurrent use
+// and is updated in place whenever the tokens are rotated.
+type TokenStore struct {
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+}
+
+func NewTokenStore(accessToken, refreshToken string) *TokenStore {
+	return &TokenStore{accessToken: accessToken, refreshToken: refreshToken}
+}
+
+func (s *TokenStore) Tokens() (accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accessToken, s.refreshToken
+}
+
+func (s *TokenStore) Set(accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessToken, s.refreshToken = accessToken, refreshToken
+}
+
+// Login exchanges the credentials for a token pair and stores it.
+func (s *TokenStore) Login(ctx context.Context, c *ClientWithResponses, username, password string) error {
+	resp, err := c.PostLoginWithResponse(ctx, func(_ context.Context, req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.JSON200 == nil || resp.JSON200.Token == nil || resp.JSON200.RefreshToken == nil {
+		return fmt.Errorf("login failed with status %d", resp.StatusCode())
+	}
+
+	s.Set(*resp.JSON200.Token, *resp.JSON200.RefreshToken)
+	return nil
+}
+
+// WithTokens authorizes requests with the access token of store. A request
+// answered with 401 is sent once more after the tokens were refreshed, so
+// callers never see an expired access token.
+func WithTokens(store *TokenStore) ClientOption {
+	return func(c *Client) error {
+		next := c.Client
+		if next == nil {
+			next = &http.Client{}
+		}
+
+		// NewClient only adds the trailing slash after the options ran.
+		server := c.Server
+		if !strings.HasSuffix(server, "/") {
+			server += "/"
+		}
+
+		c.Client = &tokenDoer{
+			next:   next,
+			server: server,
+			store:  store,
+		}
+		return nil
+	}
+}
+
+type tokenDoer struct {
+	next   HttpRequestDoer
+	server string
+	store  *TokenStore
+
+	// refreshMu makes concurrent requests that hit an expired token share a
+	// single refresh, refresh tokens can only be used once.
+	refreshMu sync.Mutex
+}
+
+func (d *tokenDoer) Do(req *http.Request) (*http.Response, error) {
+	accessToken, _ := d.store.Tokens()
+	if accessToken == "" || req.Header.Get("Authorization") != "" {
+		return d.next.Do(req)
+	}
+
+	resp, err := d.next.Do(withBearer(req, accessToken))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	retry, err := rewind(req)
+	if err != nil {
+		return resp, nil
+	}
+
+	refreshed, err := d.refresh(req, accessToken)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	return d.next.Do(withBearer(retry, refreshed))
+}
+
+// refresh rotates the tokens unless another request already did since
+// staleToken was sent.
+func (d *tokenDoer) refresh(req *http.Request, staleToken string) (string, error) {
+	d.refreshMu.Lock()
+	defer d.refreshMu.Unlock()
+
+	accessToken, refreshToken := d.store.Tokens()
+	if accessToken != staleToken {
+		return accessToken, nil
+	}
+	if refreshToken == "" {
+		return "", ErrUnauthorized
+	}
+
+	refreshReq, err := NewPostRefreshRequest(d.server, PostRefreshJSONRequestBody{RefreshToken: refreshToken})
+	if err != nil {
+		return "", err
+	}
+
+	rsp, err := d.next.Do(refreshReq.WithContext(req.Context()))
+	if err != nil {
+		return "", err
+	}
+
+	parsed, err := ParsePostRefreshResponse(rsp)
+	if err != nil {
+		return "", err
+	}
+	if parsed.JSON200 == nil || parsed.JSON200.Token == nil || parsed.JSON200.RefreshToken == nil {
+		return "", ErrUnauthorized
+	}
+
+	d.store.Set(*parsed.JSON200.Token, *parsed.JSON200.RefreshToken)
+	return *parsed.JSON200.Token, nil
+}
+
+func withBearer(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
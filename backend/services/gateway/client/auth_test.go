@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// authServer accepts "Bearer fresh" only and rotates "stale" to "fresh" on
+// /refresh, counting the refreshes it did.
+type authServer struct {
+	refreshes atomic.Int32
+	requests  atomic.Int32
+}
+
+func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/refresh" {
+		var body struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.RefreshToken != "refresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		s.refreshes.Add(1)
+		// Keep the refresh slow enough for the other requests to pile up.
+		time.Sleep(20 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"token":"fresh","refresh_token":"rotated"}`)
+		return
+	}
+
+	s.requests.Add(1)
+	if r.Header.Get("Authorization") != "Bearer fresh" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = io.WriteString(w, `[]`)
+}
+
+func newTokenClient(t *testing.T, handler http.Handler, store *TokenStore) *ClientWithResponses {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClientWithResponses(server.URL, WithTokens(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestWithTokensSharesOneRefresh(t *testing.T) {
+	server := &authServer{}
+	store := NewTokenStore("stale", "refresh")
+	c := newTokenClient(t, server, store)
+
+	const concurrent = 10
+
+	var wg sync.WaitGroup
+	statuses := make([]int, concurrent)
+	for i := range concurrent {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := c.GetSessionsWithResponse(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			statuses[i] = resp.StatusCode()
+		}()
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("request %d: got status %d, want %d", i, status, http.StatusOK)
+		}
+	}
+
+	if got := server.refreshes.Load(); got != 1 {
+		t.Errorf("got %d refreshes, want 1", got)
+	}
+
+	if access, refresh := store.Tokens(); access != "fresh" || refresh != "rotated" {
+		t.Errorf("got tokens %q and %q, want the rotated pair", access, refresh)
+	}
+}
+
+func TestWithTokensFailedRefresh(t *testing.T) {
+	server := &authServer{}
+	store := NewTokenStore("stale", "revoked")
+	c := newTokenClient(t, server, store)
+
+	resp, err := c.GetSessionsWithResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", resp.StatusCode(), http.StatusUnauthorized)
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want the first one only", got)
+	}
+}
+
+// onceReader is a body net/http cannot recreate, GetBody stays nil for it.
+type onceReader struct{ io.Reader }
+
+func TestWithTokensNonRewindableBody(t *testing.T) {
+	server := &authServer{}
+	store := NewTokenStore("stale", "refresh")
+	c := newTokenClient(t, server, store)
+
+	body := onceReader{strings.NewReader(`{"liked":true,"title":"t","text":"t"}`)}
+	resp, err := c.PostReviewsMovieIdWithBodyWithResponse(context.Background(), 1, "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("got status %d, want the original %d", resp.StatusCode(), http.StatusUnauthorized)
+	}
+	if got := server.refreshes.Load(); got != 0 {
+		t.Errorf("got %d refreshes for a request that cannot be replayed, want 0", got)
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestWithTokensReplaysBody(t *testing.T) {
+	var bodies []string
+	var mu sync.Mutex
+
+	auth := &authServer{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/refresh" {
+			raw, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, string(raw))
+			mu.Unlock()
+			r.Body = io.NopCloser(strings.NewReader(string(raw)))
+		}
+		auth.ServeHTTP(w, r)
+	})
+
+	store := NewTokenStore("stale", "refresh")
+	c := newTokenClient(t, handler, store)
+
+	const review = `{"liked":true,"title":"t","text":"t"}`
+	resp, err := c.PostReviewsMovieIdWithBodyWithResponse(context.Background(), 1, "application/json", strings.NewReader(review))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("got status %d, want %d", resp.StatusCode(), http.StatusOK)
+	}
+	if len(bodies) != 2 || bodies[0] != review || bodies[1] != review {
+		t.Errorf("got bodies %q, want the review sent twice", bodies)
+	}
+}
@@ -0,0 +1,28 @@
+// Package client is a typed client for the cinema gateway API, generated from
+// api/v1.openapi.yaml with client.yaml. Regenerate it whenever the spec
+// changes:
+//
+//	oapi-codegen -config client.yaml ../api/v1.openapi.yaml
+//
+// The generated ClientWithResponses is extended with token handling, retries
+// and pagination. Options wrap the HTTP client set before them, so
+// WithHTTPClient comes first and WithTokens last:
+//
+//	tokens := client.NewTokenStore("", "")
+//	c, err := client.NewClientWithResponses("https://cinema.example.com/api/v1",
+//		client.WithRetries(client.DefaultRetryPolicy),
+//		client.WithTokens(tokens),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	if err := tokens.Login(ctx, c, username, password); err != nil {
+//		return err
+//	}
+//	for movie, err := range c.PopularMovies(ctx, 20) {
+//		...
+//	}
+//
+// The package is its own module so other teams can depend on it without the
+// gateway. Releases are tagged as backend/services/gateway/client/vX.Y.Z.
+package client
@@ -0,0 +1,10 @@
+module github.com/allnightmarel0Ng/cinema/backend/services/gateway/client
+
+go 1.24.1
+
+require github.com/oapi-codegen/runtime v1.1.1
+
+require (
+	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+)
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// PopularMovies walks /movies/popular page by page, pageSize movies at a
+// time, until a short page. Breaking out of the loop stops fetching, an error
+// ends the sequence.
+func (c *ClientWithResponses) PopularMovies(ctx context.Context, pageSize int, reqEditors ...RequestEditorFn) iter.Seq2[Movie, error] {
+	return func(yield func(Movie, error) bool) {
+		for page := 1; ; page++ {
+			resp, err := c.GetMoviesPopularWithResponse(ctx, &GetMoviesPopularParams{Page: &page, Size: &pageSize}, reqEditors...)
+			if err == nil && resp.JSON200 == nil {
+				err = fmt.Errorf("unable to get page %d of popular movies: status %d", page, resp.StatusCode())
+			}
+			if err != nil {
+				yield(Movie{}, err)
+				return
+			}
+
+			for _, movie := range *resp.JSON200 {
+				if !yield(movie, nil) {
+					return
+				}
+			}
+
+			if len(*resp.JSON200) < pageSize {
+				return
+			}
+		}
+	}
+}
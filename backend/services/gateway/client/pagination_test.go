@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// popularServer serves total movies with ids from 1, the way /movies/popular
+// pages them, and fails from page failFrom on when it is set.
+func popularServer(t *testing.T, total, failFrom int) (*ClientWithResponses, *atomic.Int32) {
+	t.Helper()
+
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages.Add(1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		if failFrom > 0 && page >= failFrom {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		movies := make([]string, 0, size)
+		for id := (page-1)*size + 1; id <= min(page*size, total); id++ {
+			movies = append(movies, fmt.Sprintf(`{"id":%d}`, id))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(movies, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, &pages
+}
+
+func TestPopularMovies(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		pageSize  int
+		wantPages int32
+	}{
+		{"empty", 0, 5, 1},
+		{"short last page", 12, 5, 3},
+		{"full last page", 10, 5, 3},
+		{"single page", 3, 5, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, pages := popularServer(t, tt.total, 0)
+
+			next := 1
+			for movie, err := range c.PopularMovies(context.Background(), tt.pageSize) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if movie.Id == nil || *movie.Id != next {
+					t.Fatalf("got movie %v, want id %d", movie.Id, next)
+				}
+				next++
+			}
+
+			if next-1 != tt.total {
+				t.Errorf("got %d movies, want %d", next-1, tt.total)
+			}
+			if got := pages.Load(); got != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestPopularMoviesBreak(t *testing.T) {
+	c, pages := popularServer(t, 100, 0)
+
+	seen := 0
+	for _, err := range c.PopularMovies(context.Background(), 5) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen++; seen == 7 {
+			break
+		}
+	}
+
+	if got := pages.Load(); got != 2 {
+		t.Errorf("fetched %d pages, want 2", got)
+	}
+}
+
+func TestPopularMoviesError(t *testing.T) {
+	c, _ := popularServer(t, 100, 2)
+
+	movies, errs := 0, 0
+	for _, err := range c.PopularMovies(context.Background(), 5) {
+		if err != nil {
+			errs++
+			continue
+		}
+		movies++
+	}
+
+	if movies != 5 || errs != 1 {
+		t.Errorf("got %d movies and %d errors, want 5 and 1", movies, errs)
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt too.
+	MaxAttempts int
+	Initial     time.Duration
+	Max         time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Initial:     200 * time.Millisecond,
+	Max:         5 * time.Second,
+}
+
+// WithRetries retries idempotent requests that failed on the network or were
+// answered with 429, 502, 503 or 504, waiting exponentially longer with jitter
+// between attempts. A Retry-After header is honoured when it asks for longer.
+func WithRetries(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		next := c.Client
+		if next == nil {
+			next = &http.Client{}
+		}
+
+		c.Client = &retryDoer{next: next, policy: policy}
+		return nil
+	}
+}
+
+type retryDoer struct {
+	next   HttpRequestDoer
+	policy RetryPolicy
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !idempotent(req.Method) {
+		return d.next.Do(req)
+	}
+
+	delay := max(d.policy.Initial, time.Millisecond)
+	for attempt := 1; ; attempt++ {
+		resp, err := d.next.Do(req)
+		if attempt >= d.policy.MaxAttempts || !retryable(resp, err) {
+			return resp, err
+		}
+
+		wait := delay/2 + rand.N(delay/2+1)
+		if resp != nil {
+			wait = max(wait, retryAfter(resp))
+			resp.Body.Close()
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		delay = min(delay*2, max(d.policy.Max, d.policy.Initial))
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// rewind returns a copy of req whose body can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be sent again")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
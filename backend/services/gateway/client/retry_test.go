@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{
+	MaxAttempts: 3,
+	Initial:     time.Millisecond,
+	Max:         time.Millisecond,
+}
+
+// flakyServer fails the first failures requests with status and answers the
+// rest with 200.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":1}`)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts
+}
+
+func TestWithRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		failures     int32
+		status       int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{"success", http.MethodGet, 0, 0, http.StatusOK, 1},
+		{"recovers", http.MethodGet, 2, http.StatusServiceUnavailable, http.StatusOK, 3},
+		{"gives up", http.MethodGet, 5, http.StatusBadGateway, http.StatusBadGateway, 3},
+		{"too many requests", http.MethodGet, 1, http.StatusTooManyRequests, http.StatusOK, 2},
+		{"gateway timeout", http.MethodDelete, 1, http.StatusGatewayTimeout, http.StatusOK, 2},
+		{"not retryable status", http.MethodGet, 1, http.StatusInternalServerError, http.StatusInternalServerError, 1},
+		{"not idempotent", http.MethodPost, 1, http.StatusServiceUnavailable, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, attempts := flakyServer(t, tt.failures, tt.status, nil)
+
+			req, err := http.NewRequest(tt.method, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := (&retryDoer{next: server.Client(), policy: fastRetries}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWithRetriesHonoursRetryAfter(t *testing.T) {
+	server, attempts := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+
+	c, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetries(fastRetries))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := time.Now()
+	resp, err := c.GetMoviesIdWithResponse(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("got status %d, want %d", resp.StatusCode(), http.StatusOK)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("got %d attempts, want 2", got)
+	}
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the second asked for", elapsed)
+	}
+}
+
+func TestWithRetriesStopsOnCancel(t *testing.T) {
+	server, attempts := flakyServer(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"60"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = (&retryDoer{next: server.Client(), policy: fastRetries}).Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
+
+func TestWithRetriesNonRewindableBody(t *testing.T) {
+	server, attempts := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, onceReader{strings.NewReader("body")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = (&retryDoer{next: server.Client(), policy: fastRetries}).Do(req); err == nil {
+		t.Error("got no error for a body that cannot be sent again")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/application/catalog"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/config"
+	searchindexes "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/search_indexes"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	importMoviesUsage  = "import-movies [-batch-size n] <file.ndjson|->"
+	exportCatalogUsage = "export-catalog [-page-size n] [-o file.ndjson]"
+)
+
+func runReindexSearch(ctx context.Context, cfg config.Config, logger *logrus.Logger, _ []string) error {
+	db, err := openPostgres(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := searchindexes.NewGORMRepository(db, cfg.Admin.Timeout).Reindex(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("search indexes rebuilt")
+	return nil
+}
+
+func runRecomputeVotes(ctx context.Context, cfg config.Config, logger *logrus.Logger, args []string) error {
+	movieIDs := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid movie id %q", arg)
+		}
+		movieIDs = append(movieIDs, id)
+	}
+
+	repos, err := openSharedRepositories(ctx, cfg, cfg.Admin.Timeout)
+	if err != nil {
+		return err
+	}
+
+	if len(movieIDs) > 0 {
+		if err := repos.movies.RecomputeVotes(ctx, movieIDs); err != nil {
+			return err
+		}
+	} else if err := repos.movies.RecomputeAllVotes(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("votes recomputed")
+	return nil
+}
+
+func runImportMovies(ctx context.Context, cfg config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-movies", flag.ContinueOnError)
+	batchSize := flags.Int("batch-size", 500, "movies per InsertMovies call")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 || *batchSize <= 0 {
+		return errors.New("usage: gateway " + importMoviesUsage)
+	}
+
+	var input io.Reader = os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	repos, err := openSharedRepositories(ctx, cfg, cfg.Admin.Timeout)
+	if err != nil {
+		return err
+	}
+
+	imported, err := catalog.New(repos.movies, repos.invalidations).Import(ctx, input, *batchSize)
+	logger.Infof("imported %d movies", imported)
+	return err
+}
+
+func runExportCatalog(ctx context.Context, cfg config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("export-catalog", flag.ContinueOnError)
+	pageSize := flags.Int("page-size", 500, "movies read per query")
+	output := flags.String("o", "-", "output file, - for stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 || *pageSize <= 0 {
+		return errors.New("usage: gateway " + exportCatalogUsage)
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	repos, err := openSharedRepositories(ctx, cfg, cfg.Admin.Timeout)
+	if err != nil {
+		return err
+	}
+
+	exported, err := catalog.New(repos.movies, repos.invalidations).Export(ctx, out, *pageSize)
+	logger.Infof("exported %d movies", exported)
+	return err
+}
@@ -5,182 +5,68 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
-	"time"
 
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/application/poll"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/config"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/auth"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/actors"
-	moviesubscriber "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/movie_subscriber"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/movies"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/ratings"
-	requestlogs "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/request_logs"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/reviews"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/controllers"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
-	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
-	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
-	"go.opentelemetry.io/otel"
-	"gorm.io/driver/clickhouse"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	gormlogger "gorm.io/gorm/logger"
-	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
+type command struct {
+	usage string
+	run   func(ctx context.Context, cfg config.Config, logger *logrus.Logger, args []string) error
+}
+
+var commands = map[string]command{
+	"serve":           {usage: "serve", run: runServe},
+	"migrate":         {usage: "migrate up|down [steps]|status", run: runMigrate},
+	"reindex-search":  {usage: "reindex-search", run: runReindexSearch},
+	"recompute-votes": {usage: "recompute-votes [movie-id...]", run: runRecomputeVotes},
+	"import-movies":   {usage: importMoviesUsage, run: runImportMovies},
+	"export-catalog":  {usage: exportCatalogUsage, run: runExportCatalog},
+}
+
 func main() {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	cfg := config.MustLoad()
-
-	traceFunc := tracing.MustInit(ctx, cfg.Collector.Addr)
-	defer traceFunc()
-
-	tracer := otel.Tracer("gateway")
-
-	metricFunc := metrics.MustInit(ctx, cfg.Collector.Addr)
-	defer metricFunc()
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		cfg.Database.Host, cfg.Database.User, cfg.Database.Password, cfg.Database.Name, cfg.Database.Port)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
-	if err != nil {
-		panic(err)
-	}
-
-	db.Logger = db.Logger.LogMode(gormlogger.Silent)
-
-	if err = db.AutoMigrate(
-		&entities.Actor{},
-		&entities.Genre{},
-		&entities.Movie{},
-		&entities.Review{},
-		&entities.Rating{},
-	); err != nil {
-		panic(err)
-	}
-
-	if err = db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm;").Error; err != nil {
-		panic(err)
-	}
-
-	if err = db.Exec(`
-    	CREATE INDEX IF NOT EXISTS idx_movies_title_trgm 
-    	ON movies USING gin (title gin_trgm_ops)
-	`).Error; err != nil {
-		panic(err)
-	}
-
-	if err = db.Exec(`
-    	CREATE INDEX IF NOT EXISTS idx_actors_name_trgm 
-    	ON actors USING gin (name gin_trgm_ops)
-	`).Error; err != nil {
-		panic(err)
-	}
-
-	if err = db.Use(gormtracing.NewPlugin()); err != nil {
-		panic(err)
-	}
+	ctx = ctxlogrus.ToContext(ctx, logger.WithFields(logrus.Fields{"service": "gateway"}))
 
-	clickhouse, err := gorm.Open(clickhouse.Open(fmt.Sprintf("clickhouse://%s:%s@%s:%s/%s",
-		cfg.Clickhouse.User, cfg.Clickhouse.Password, cfg.Clickhouse.Host, cfg.Clickhouse.Port, cfg.Clickhouse.Name)))
-	if err != nil {
-		panic(err)
+	// Running the binary without arguments keeps starting the server, as it
+	// did before it had subcommands.
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
 
-	if err = clickhouse.AutoMigrate(&entities.RequestLog{}); err != nil {
-		panic(err)
+	cmd, ok := commands[name]
+	if !ok {
+		usage()
+		os.Exit(2)
 	}
 
-	if err = clickhouse.Use(gormtracing.NewPlugin()); err != nil {
-		panic(err)
-	}
+	cfg := config.MustLoad()
 
-	if err = clickhouse.Exec(`
-        ALTER TABLE request_logs 
-        MODIFY TTL toDateTime(timestamp) + INTERVAL 30 DAY
-    `).Error; err != nil {
-		panic(err)
+	if err := cmd.run(ctx, cfg, logger, args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err.Error())
+		os.Exit(1)
 	}
+}
 
-	requestLogs := requestlogs.NewGORMRepository(clickhouse, cfg.Clickhouse.Timeout)
-
-	authClient := auth.NewHTTPClient(cfg.Auth.Host, cfg.Auth.Timeout)
-
-	moviesRepo := movies.NewGORMRepository(db, cfg.Database.Timeout)
-	actorsRepo := actors.NewGORMRepository(db, cfg.Database.Timeout)
-	ratingsRepo := ratings.NewGORMRepository(db, cfg.Database.Timeout)
-	reviewsRepo := reviews.NewGORMRepository(db, cfg.Database.Timeout)
-
-	moviesController := controllers.NewMovies(moviesRepo)
-	actorsController := controllers.NewActors(actorsRepo)
-	authController := controllers.NewAuth(authClient)
-	ratingsController := controllers.NewRatings(ratingsRepo, moviesRepo)
-	reviewsController := controllers.NewReviews(reviewsRepo)
-	usersController := controllers.NewUsers(reviewsRepo, ratingsRepo, authClient)
-
-	mainController := controllers.Main{
-		Actors:  actorsController,
-		Auth:    authController,
-		Movies:  moviesController,
-		Ratings: ratingsController,
-		Reviews: reviewsController,
-		Users:   usersController,
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	gin.SetMode("release")
-	router := gin.New()
-
-	router.Use(cors.New(cors.Config{
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowAllOrigins:  true,
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	router.Use(otelgin.Middleware("gateway"))
-
-	api.RegisterHandlersWithOptions(router, mainController, api.GinServerOptions{
-		BaseURL: "/api",
-		Middlewares: []api.MiddlewareFunc{
-			api.MiddlewareFunc(middleware.NewMetric()),
-			api.MiddlewareFunc(middleware.NewLogger(logger.WithFields(logrus.Fields{"service": "gateway"}))),
-			api.MiddlewareFunc(middleware.NewAuth(authClient)),
-			api.MiddlewareFunc(middleware.NewSendRequestLog(requestLogs, tracer)),
-		},
-	})
-
-	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr, Password: "", DB: 0})
-	if err := redisClient.Echo(context.Background(), nil).Err(); err != nil {
-		panic(err)
+	fmt.Fprintln(os.Stderr, "usage: gateway <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
 	}
-
-	subscriber := moviesubscriber.NewRedisSubcriber(redisClient, cfg.Subscriber)
-
-	ctx = ctxlogrus.ToContext(ctx, logger.WithFields(logrus.Fields{"service": "gateway"}))
-
-	go poll.New(subscriber, moviesRepo).Poll(ctx)
-
-	go router.Run(":8080")
-
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
-
-	<-shutdown
 }
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/config"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/migrations"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: gateway migrate up|down [steps]|status"
+
+func migrators(db, clickhouse *gorm.DB) ([]*migrations.Migrator, error) {
+	pg, err := migrations.NewPostgres(db)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := migrations.NewClickhouse(clickhouse)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*migrations.Migrator{pg, ch}, nil
+}
+
+func verifySchema(ctx context.Context, db, clickhouse *gorm.DB) error {
+	all, err := migrators(db, clickhouse)
+	if err != nil {
+		return err
+	}
+
+	for _, migrator := range all {
+		if err := migrator.Verify(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runMigrate(ctx context.Context, cfg config.Config, _ *logrus.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := openPostgres(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	clickhouse, err := openClickhouse(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	all, err := migrators(db, clickhouse)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return migrations.WithLock(ctx, db, func(ctx context.Context) error {
+			for _, migrator := range all {
+				count, err := migrator.Up(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s: applied %d migration(s)\n", migrator.Database, count)
+			}
+			return nil
+		})
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return errors.New(migrateUsage)
+			}
+		}
+
+		// Reverting goes in the opposite order of applying.
+		return migrations.WithLock(ctx, db, func(ctx context.Context) error {
+			for i := len(all) - 1; i >= 0; i-- {
+				count, err := all[i].Down(ctx, steps)
+				if errors.Is(err, migrations.ErrNothingToRevert) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s: reverted %d migration(s)\n", all[i].Database, count)
+			}
+			return nil
+		})
+
+	case "status":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATABASE\tVERSION\tNAME\tAPPLIED AT")
+		for _, migrator := range all {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.Applied {
+					appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				fmt.Fprintf(w, "%s\t%04d\t%s\t%s\n", migrator.Database, status.Version, status.Name, appliedAt)
+			}
+		}
+		return w.Flush()
+
+	default:
+		return errors.New(migrateUsage)
+	}
+}
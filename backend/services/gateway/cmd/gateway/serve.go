@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/application/deletion"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/application/poll"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/config"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/auth"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/oidc"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/lifecycle"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
+	accountdeletions "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/account_deletions"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/events"
+	loginstates "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/login_states"
+	moviesubscriber "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/movie_subscriber"
+	ratelimits "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/rate_limits"
+	requestlogs "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/request_logs"
+	tokenrevocations "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/token_revocations"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/trending"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/apiv2"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/controllers"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/graph"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/health"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+func runServe(ctx context.Context, cfg config.Config, logger *logrus.Logger, _ []string) error {
+	manager := lifecycle.New()
+
+	traceFunc := telemetry.MustInitTracing(ctx, "gateway", cfg.Collector.Addr)
+	metricFunc := metrics.Init(ctx, cfg.Collector.Addr, cfg.Metrics)
+
+	manager.Add(lifecycle.Component{
+		Name: "telemetry",
+		Stop: func(ctx context.Context) error {
+			return errors.Join(traceFunc(ctx), metricFunc(ctx))
+		},
+		StopTimeout: cfg.Shutdown.TelemetryTimeout,
+	})
+
+	db, err := openPostgres(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	clickhouse, err := openClickhouse(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := verifySchema(ctx, db, clickhouse); err != nil {
+		return err
+	}
+
+	if err := errors.Join(db.Use(gormtracing.NewPlugin()), db.Use(metrics.NewGORMPlugin("postgres"))); err != nil {
+		return err
+	}
+
+	if err := errors.Join(clickhouse.Use(gormtracing.NewPlugin()), clickhouse.Use(metrics.NewGORMPlugin("clickhouse"))); err != nil {
+		return err
+	}
+
+	requestLogs := requestlogs.NewBatchWriter(clickhouse, cfg.Clickhouse.RequestLogs, cfg.Clickhouse.Timeout)
+	eventsRepo := events.NewBatchWriter(clickhouse, cfg.Clickhouse.Events, cfg.Clickhouse.Timeout)
+
+	redisClient, err := openRedis(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	manager.Add(lifecycle.Component{
+		Name: "connections",
+		Stop: func(context.Context) error {
+			return errors.Join(closeDB(db), closeDB(clickhouse), redisClient.Close())
+		},
+	})
+
+	revocations := tokenrevocations.NewRedisRepository(redisClient, cfg.Auth.Timeout)
+	authClient := auth.NewJWTClient(ctx, auth.NewHTTPClient(cfg.Auth.Host, cfg.Auth.Timeout), revocations, cfg.Auth)
+
+	shared := newSharedRepositories(cfg, db, redisClient, cfg.Database.Timeout)
+	moviesRepo := shared.movies
+	actorsRepo := shared.actors
+	ratingsRepo := shared.ratings
+	reviewsRepo := shared.reviews
+
+	trendingRepo := trending.NewRedisRepository(redisClient, cfg.Trending, cfg.Redis.Timeout)
+
+	moviesController := controllers.NewMovies(moviesRepo, eventsRepo, trendingRepo)
+	actorsController := controllers.NewActors(actorsRepo)
+	authController := controllers.NewAuth(authClient)
+	ratingsController := controllers.NewRatings(ratingsRepo, moviesRepo, eventsRepo, trendingRepo)
+	reviewsController := controllers.NewReviews(reviewsRepo, trendingRepo)
+	sessionsController := controllers.NewSessions(authClient)
+	accountDeletionsRepo := accountdeletions.NewGORMRepository(db, cfg.Database.Timeout)
+	meController := controllers.NewMe(reviewsRepo, ratingsRepo, requestLogs, accountDeletionsRepo, authClient)
+	oidcController := controllers.NewOIDC(
+		oidc.NewHTTPClient(cfg.OIDC),
+		authClient,
+		loginstates.NewRedisRepository(redisClient, cfg.Auth.Timeout),
+		cfg.OIDC.StateTTL,
+		cfg.OIDC.FrontendRedirect,
+	)
+	usersController := controllers.NewUsers(reviewsRepo, ratingsRepo, authClient)
+
+	analyticsController := controllers.NewAnalytics(requestlogs.NewGORMAnalytics(clickhouse, cfg.Clickhouse.AnalyticsTimeout))
+
+	mainController := controllers.Main{
+		Actors:    actorsController,
+		Analytics: analyticsController,
+		Auth:      authController,
+		Me:        meController,
+		Movies:    moviesController,
+		OIDC:      oidcController,
+		Ratings:   ratingsController,
+		Reviews:   reviewsController,
+		Sessions:  sessionsController,
+		Users:     usersController,
+	}
+
+	gin.SetMode("release")
+	router := gin.New()
+
+	// Clickhouse only stores request logs and most endpoints work without the
+	// auth service, so neither takes the gateway out of rotation.
+	checker := health.New(cfg.Health.Timeout)
+	checker.Add(health.Check{Name: "postgres", Critical: true, Probe: health.SQL(db.DB)})
+	checker.Add(health.Check{Name: "redis", Critical: true, Probe: health.Redis(redisClient)})
+	checker.Add(health.Check{Name: "clickhouse", Probe: health.SQL(clickhouse.DB)})
+	checker.Add(health.Check{Name: "auth", Probe: health.HTTP("http://" + cfg.Auth.Host + "/.well-known/jwks.json")})
+
+	// Probes are registered before the middlewares so they are not traced,
+	// logged or rate limited.
+	router.GET("/healthz", gin.WrapF(checker.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+
+	router.Use(cors.New(cors.Config{
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowAllOrigins:  true,
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "If-None-Match", "If-Modified-Since"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Last-Modified", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "Deprecation", "Sunset", "Link"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+
+	router.Use(otelgin.Middleware("gateway"))
+
+	router.Use(middleware.NewMetric())
+	router.Use(middleware.NewSendRequestLog(requestLogs))
+
+	rateLimits := ratelimits.NewRedisRepository(redisClient, cfg.Auth.Timeout)
+	router.Use(middleware.NewLoginGuard(rateLimits, cfg.RateLimit.Login))
+
+	router.NoRoute(func(c *gin.Context) {
+		problem.Abort(c, http.StatusNotFound, problem.CodeNotFound, "")
+	})
+
+	requestLogger := middleware.NewLogger(logger.WithFields(logrus.Fields{"service": "gateway"}))
+	clientRateLimit := middleware.NewClientRateLimit(rateLimits, cfg.RateLimit.Client)
+	rateLimit := middleware.NewRateLimit(rateLimits, cfg.RateLimit.Default, cfg.RateLimit.Routes)
+
+	// Requests are authenticated before they are validated, so callers without
+	// a token do not learn about the schema of protected routes. The client
+	// budget comes first so rejected requests are counted as well, the route
+	// policies run per user once the request is authenticated.
+	groupMiddlewares := []gin.HandlerFunc{
+		requestLogger,
+		clientRateLimit,
+		middleware.NewAuth(authClient),
+	}
+
+	handlerMiddlewares := []gin.HandlerFunc{
+		middleware.NewAdminOnly(cfg.Admin.UserIDs),
+		rateLimit,
+		middleware.NewCacheControl(cfg.HTTPCache.Default, cfg.HTTPCache.Routes),
+	}
+
+	v1Middlewares := make([]api.MiddlewareFunc, 0, len(handlerMiddlewares))
+	v2Middlewares := make([]apiv2.MiddlewareFunc, 0, len(handlerMiddlewares))
+	for _, handler := range handlerMiddlewares {
+		v1Middlewares = append(v1Middlewares, api.MiddlewareFunc(handler))
+		v2Middlewares = append(v2Middlewares, apiv2.MiddlewareFunc(handler))
+	}
+
+	v1Spec, err := api.GetSwagger()
+	if err != nil {
+		return err
+	}
+
+	v2Spec, err := apiv2.GetSwagger()
+	if err != nil {
+		return err
+	}
+
+	// The unversioned routes keep serving the first version until their sunset.
+	legacyGroup, err := apiGroup(router, v1Spec, "/api", &middleware.Deprecation{
+		Since:     cfg.API.LegacyDeprecatedAt,
+		Sunset:    cfg.API.LegacySunset,
+		Successor: "/api/v1",
+	}, cfg.OpenAPI.ValidateResponses, groupMiddlewares...)
+	if err != nil {
+		return err
+	}
+
+	v1Group, err := apiGroup(router, v1Spec, "/api/v1", nil, cfg.OpenAPI.ValidateResponses, groupMiddlewares...)
+	if err != nil {
+		return err
+	}
+
+	v2Group, err := apiGroup(router, v2Spec, "/api/v2", nil, cfg.OpenAPI.ValidateResponses, groupMiddlewares...)
+	if err != nil {
+		return err
+	}
+
+	v1Options := api.GinServerOptions{
+		ErrorHandler: controllers.ErrorHandler,
+		Middlewares:  v1Middlewares,
+	}
+	api.RegisterHandlersWithOptions(legacyGroup, mainController, v1Options)
+	api.RegisterHandlersWithOptions(v1Group, mainController, v1Options)
+
+	apiv2.RegisterHandlersWithOptions(v2Group, controllers.V2{Main: mainController}, apiv2.GinServerOptions{
+		ErrorHandler: controllers.ErrorHandler,
+		Middlewares:  v2Middlewares,
+	})
+
+	// The graph only exposes public data, so it is neither authenticated nor
+	// checked against an OpenAPI spec.
+	graphHandler := graph.NewHandler(graph.NewResolver(moviesRepo, actorsRepo, reviewsRepo, ratingsRepo, authClient), cfg.GraphQL)
+	router.Match([]string{http.MethodGet, http.MethodPost}, "/graphql", requestLogger, clientRateLimit, rateLimit, gin.WrapH(graphHandler))
+
+	subscriber := moviesubscriber.NewRedisSubcriber(redisClient, cfg.Subscriber)
+
+	manager.Add(lifecycle.Component{
+		Name: "request logs",
+		Run: func(ctx context.Context) error {
+			requestLogs.Run(ctx)
+			return nil
+		},
+		StopTimeout: cfg.Shutdown.RequestLogTimeout,
+	})
+
+	manager.Add(lifecycle.Component{
+		Name: "events",
+		Run: func(ctx context.Context) error {
+			eventsRepo.Run(ctx)
+			return nil
+		},
+		StopTimeout: cfg.Shutdown.RequestLogTimeout,
+	})
+
+	manager.Add(lifecycle.Component{
+		Name: "cache invalidations",
+		Run: func(ctx context.Context) error {
+			shared.cache.Listen(ctx, shared.invalidations.Subscribe(ctx))
+			return nil
+		},
+	})
+
+	poller := poll.New(subscriber, moviesRepo, shared.invalidations)
+	checker.Add(health.Check{Name: "subscriber", Probe: health.Lag(poller.LastBatch, cfg.Health.MaxSubscriberLag)})
+
+	manager.Add(lifecycle.Component{
+		Name: "movies poller",
+		Run: func(ctx context.Context) error {
+			poller.Poll(ctx)
+			return nil
+		},
+		StopTimeout: cfg.Shutdown.WorkerTimeout,
+	})
+
+	deletions := deletion.New(accountDeletionsRepo, ratingsRepo, reviewsRepo, moviesRepo, authClient, cfg.AccountDeletion)
+	manager.Add(lifecycle.Component{
+		Name: "account deletions",
+		Run: func(ctx context.Context) error {
+			deletions.Run(ctx)
+			return nil
+		},
+		StopTimeout: cfg.Shutdown.WorkerTimeout,
+	})
+
+	server := &http.Server{
+		Addr:              ":8080",
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	manager.Add(lifecycle.Component{
+		Name: "http server",
+		Run: func(context.Context) error {
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop:        server.Shutdown,
+		StopTimeout: cfg.Shutdown.HTTPTimeout,
+	})
+
+	return manager.Run(ctx)
+}
+
+// apiGroup mounts a version of the API under basePath, validated against and
+// deprecated according to its spec. The middlewares run before validation.
+func apiGroup(router *gin.Engine, spec *openapi3.T, basePath string, mount *middleware.Deprecation, validateResponses bool, middlewares ...gin.HandlerFunc) (*gin.RouterGroup, error) {
+	validator, err := middleware.NewOpenAPIValidator(spec, basePath, validateResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecation, err := middleware.NewDeprecation(spec, basePath, mount)
+	if err != nil {
+		return nil, err
+	}
+
+	handlers := append([]gin.HandlerFunc{deprecation}, middlewares...)
+	return router.Group(basePath, append(handlers, validator)...), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/config"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/cache"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/actors"
+	cacheinvalidations "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/cache_invalidations"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/movies"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/ratings"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/reviews"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/health"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/clickhouse"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// sharedRepositories holds the repositories shared by the server and the admin
+// commands, so both see the same cache and invalidation wiring.
+type sharedRepositories struct {
+	movies        repositories.Movies
+	actors        repositories.Actors
+	ratings       repositories.Ratings
+	reviews       repositories.Reviews
+	invalidations repositories.CacheInvalidations
+	cache         *cache.Cache
+}
+
+func newSharedRepositories(cfg config.Config, db *gorm.DB, redisClient *redis.Client, timeout time.Duration) sharedRepositories {
+	readCache := cache.New(redisClient, cfg.Cache)
+	invalidations := cacheinvalidations.NewRedisRepository(redisClient, cfg.CacheInvalidations, cfg.Cache.RedisTimeout)
+
+	return sharedRepositories{
+		movies:        movies.NewCachedRepository(movies.NewGORMRepository(db, timeout), readCache, invalidations),
+		actors:        actors.NewCachedRepository(actors.NewGORMRepository(db, timeout), readCache),
+		ratings:       ratings.NewGORMRepository(db, timeout),
+		reviews:       reviews.NewGORMRepository(db, timeout),
+		invalidations: invalidations,
+		cache:         readCache,
+	}
+}
+
+// openSharedRepositories is what the admin commands use, they only need
+// Postgres and Redis.
+func openSharedRepositories(ctx context.Context, cfg config.Config, timeout time.Duration) (sharedRepositories, error) {
+	db, err := openPostgres(ctx, cfg)
+	if err != nil {
+		return sharedRepositories{}, err
+	}
+
+	redisClient, err := openRedis(ctx, cfg)
+	if err != nil {
+		return sharedRepositories{}, err
+	}
+
+	return newSharedRepositories(cfg, db, redisClient, timeout), nil
+}
+
+// openPostgres, openClickhouse and openRedis wait for their dependency with
+// backoff, so the gateway can be started before the databases are up.
+func openPostgres(ctx context.Context, cfg config.Config) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		cfg.Database.Host, cfg.Database.User, cfg.Database.Password, cfg.Database.Name, cfg.Database.Port)
+
+	var db *gorm.DB
+	err := health.Retry(ctx, cfg.Startup, "postgres", func(context.Context) (err error) {
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	db.Logger = db.Logger.LogMode(gormlogger.Silent)
+
+	return db, nil
+}
+
+func openClickhouse(ctx context.Context, cfg config.Config) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("clickhouse://%s:%s@%s:%s/%s",
+		cfg.Clickhouse.User, cfg.Clickhouse.Password, cfg.Clickhouse.Host, cfg.Clickhouse.Port, cfg.Clickhouse.Name)
+
+	var db *gorm.DB
+	err := health.Retry(ctx, cfg.Startup, "clickhouse", func(context.Context) (err error) {
+		db, err = gorm.Open(clickhouse.Open(dsn))
+		return err
+	})
+
+	return db, err
+}
+
+func openRedis(ctx context.Context, cfg config.Config) (*redis.Client, error) {
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr, Password: "", DB: 0})
+
+	err := health.Retry(ctx, cfg.Startup, "redis", func(ctx context.Context) error {
+		return redisClient.Echo(ctx, nil).Err()
+	})
+	if err != nil {
+		redisClient.Close()
+		return nil, err
+	}
+
+	return redisClient, nil
+}
+
+func closeDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
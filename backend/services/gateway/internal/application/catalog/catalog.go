@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+// maxLineSize allows movies with a full cast on a single NDJSON line.
+const maxLineSize = 16 << 20
+
+// Catalog moves the movie catalog in and out of the database as NDJSON, one
+// movie per line in the same shape the API returns. Export output can be fed
+// back to Import.
+type Catalog struct {
+	movies        repositories.Movies
+	invalidations repositories.CacheInvalidations
+}
+
+func New(movies repositories.Movies, invalidations repositories.CacheInvalidations) *Catalog {
+	return &Catalog{
+		movies:        movies,
+		invalidations: invalidations,
+	}
+}
+
+func (c *Catalog) Import(ctx context.Context, r io.Reader, batchSize int) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+
+	imported := 0
+	batch := make([]entities.Movie, 0, batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := c.movies.InsertMovies(ctx, batch); err != nil {
+			return err
+		}
+
+		if err := c.invalidations.Publish(ctx, entities.NewCatalogInvalidation(batch)); err != nil {
+			ctxlogrus.Extract(ctx).Warnf("unable to publish cache invalidation: %s", err.Error())
+		}
+
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var movie entities.Movie
+		if err := json.Unmarshal(scanner.Bytes(), &movie); err != nil {
+			return imported, fmt.Errorf("line %d: %w", line, err)
+		}
+		if movie.TheMovieDBID == 0 {
+			return imported, fmt.Errorf("line %d: tmdb_id is required", line)
+		}
+
+		batch = append(batch, movie)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return imported, err
+	}
+
+	return imported, flush()
+}
+
+func (c *Catalog) Export(ctx context.Context, w io.Writer, pageSize int) (int, error) {
+	encoder := json.NewEncoder(w)
+
+	exported, afterID := 0, 0
+	for {
+		page, err := c.movies.List(ctx, afterID, pageSize)
+		if err != nil {
+			return exported, err
+		}
+
+		for _, movie := range page {
+			if err := encoder.Encode(movie); err != nil {
+				return exported, err
+			}
+			afterID = movie.ID
+		}
+		exported += len(page)
+
+		if len(page) < pageSize {
+			return exported, nil
+		}
+	}
+}
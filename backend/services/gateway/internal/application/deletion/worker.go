@@ -0,0 +1,203 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+const batchSize = 10
+
+type Config struct {
+	Interval time.Duration
+
+	// MaxAttempts is the number of failures after which a job is marked as
+	// failed and left alone, 0 retries forever.
+	MaxAttempts int
+	// Backoff is the wait after the first failure, doubled after every
+	// following one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Worker erases accounts step by step. Progress is stored after every step
+// and each step is safe to repeat, so a job interrupted by a restart or a
+// failing dependency continues where it stopped on a later tick.
+type Worker struct {
+	deletions repositories.AccountDeletions
+	ratings   repositories.Ratings
+	reviews   repositories.Reviews
+	movies    repositories.Movies
+	auth      clients.Auth
+	cfg       Config
+
+	now func() time.Time
+}
+
+func New(deletions repositories.AccountDeletions, ratings repositories.Ratings, reviews repositories.Reviews, movies repositories.Movies, auth clients.Auth, cfg Config) *Worker {
+	return &Worker{
+		deletions: deletions,
+		ratings:   ratings,
+		reviews:   reviews,
+		movies:    movies,
+		auth:      auth,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.processBatch(ctx)
+		}
+	}
+}
+
+// processBatch finishes the job it is working on when ctx is cancelled and
+// leaves the rest of the batch for the next run.
+func (w *Worker) processBatch(ctx context.Context) {
+	jobs, err := w.deletions.GetUnfinished(ctx, batchSize)
+	if err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to get account deletions: %s", err.Error())
+		return
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if job.Attempts > 0 && w.now().Sub(job.UpdatedAt) < w.backoff(job.Attempts) {
+			continue
+		}
+
+		if err := w.process(context.WithoutCancel(ctx), job); err != nil {
+			ctxlogrus.Extract(ctx).Warnf("account deletion of user %d failed at step %s: %s", job.UserID, job.Step, err.Error())
+		}
+	}
+}
+
+func (w *Worker) process(ctx context.Context, job entities.AccountDeletion) error {
+	for job.Step != entities.AccountDeletionDone {
+		next, err := w.step(ctx, &job)
+		if err != nil {
+			job.Attempts++
+			job.LastError = fmt.Sprintf("%s: %s", job.Step, err.Error())
+			if w.cfg.MaxAttempts > 0 && job.Attempts >= w.cfg.MaxAttempts {
+				ctxlogrus.Extract(ctx).Errorf("account deletion of user %d failed %d times, giving up", job.UserID, job.Attempts)
+				job.Step = entities.AccountDeletionFailed
+			}
+			if updateErr := w.deletions.Update(ctx, job); updateErr != nil {
+				return errors.Join(err, updateErr)
+			}
+			return err
+		}
+
+		job.Step = next
+		job.LastError = ""
+		if err := w.deletions.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	ctxlogrus.Extract(ctx).Infof("account of user %d deleted", job.UserID)
+	return nil
+}
+
+func (w *Worker) step(ctx context.Context, job *entities.AccountDeletion) (entities.AccountDeletionStep, error) {
+	switch job.Step {
+	case entities.AccountDeletionPending:
+		return entities.AccountDeletionRatingsDeleted, w.deleteRatings(ctx, job)
+
+	case entities.AccountDeletionRatingsDeleted:
+		return entities.AccountDeletionReviewsDeleted, w.reviews.DeleteUserReviews(ctx, job.UserID)
+
+	case entities.AccountDeletionReviewsDeleted:
+		return entities.AccountDeletionVotesRecomputed, w.movies.RecomputeVotes(ctx, job.MovieIDs)
+
+	case entities.AccountDeletionVotesRecomputed:
+		// The user may have logged in again since the deletion was requested,
+		// the sessions are revoked through the gateway client so their access
+		// tokens stop verifying locally too.
+		if err := w.auth.RevokeSessions(ctx, job.UserID); err != nil && !errors.Is(err, clients.ErrNotFound) {
+			return "", err
+		}
+
+		err := w.auth.DeleteAccount(ctx, job.UserID)
+		if errors.Is(err, clients.ErrNotFound) {
+			err = nil
+		}
+		return entities.AccountDeletionAccountDeleted, err
+
+	case entities.AccountDeletionAccountDeleted:
+		// Nothing can be written on behalf of the user anymore, so this pass
+		// erases what was written while the previous steps ran.
+		if err := w.deleteRatings(ctx, job); err != nil {
+			return "", err
+		}
+		if err := w.reviews.DeleteUserReviews(ctx, job.UserID); err != nil {
+			return "", err
+		}
+		return entities.AccountDeletionDone, w.movies.RecomputeVotes(ctx, job.MovieIDs)
+
+	default:
+		return "", fmt.Errorf("unknown account deletion step %q", job.Step)
+	}
+}
+
+func (w *Worker) deleteRatings(ctx context.Context, job *entities.AccountDeletion) error {
+	ratings, err := w.ratings.GetUserRatings(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Remember affected movies before the ratings are gone, otherwise a
+	// retry would not know which aggregates to rebuild.
+	job.MovieIDs = mergeMovieIDs(job.MovieIDs, ratings)
+	if err := w.deletions.Update(ctx, *job); err != nil {
+		return err
+	}
+
+	return w.ratings.DeleteUserRatings(ctx, job.UserID)
+}
+
+// backoff is the time to wait before retrying a job that failed attempts
+// times in a row.
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.cfg.Backoff
+	for i := 1; i < attempts && delay < w.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, w.cfg.MaxBackoff)
+}
+
+func mergeMovieIDs(movieIDs []int, ratings []entities.Rating) []int {
+	seen := make(map[int]struct{}, len(movieIDs))
+	for _, id := range movieIDs {
+		seen[id] = struct{}{}
+	}
+
+	for _, rating := range ratings {
+		if _, ok := seen[rating.MovieID]; ok {
+			continue
+		}
+		seen[rating.MovieID] = struct{}{}
+		movieIDs = append(movieIDs, rating.MovieID)
+	}
+
+	return movieIDs
+}
@@ -0,0 +1,223 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+)
+
+// calls records the order in which the dependencies of the worker are used,
+// shared by all the stubs of a test.
+type calls []string
+
+type deletionsStub struct {
+	repositories.AccountDeletions
+
+	jobs map[int]entities.AccountDeletion
+	now  func() time.Time
+}
+
+func (s *deletionsStub) GetUnfinished(context.Context, int) ([]entities.AccountDeletion, error) {
+	var jobs []entities.AccountDeletion
+	for _, job := range s.jobs {
+		if job.Step != entities.AccountDeletionDone && job.Step != entities.AccountDeletionFailed {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (s *deletionsStub) Update(_ context.Context, job entities.AccountDeletion) error {
+	job.UpdatedAt = s.now()
+	s.jobs[job.UserID] = job
+	return nil
+}
+
+type ratingsStub struct {
+	repositories.Ratings
+
+	calls   *calls
+	ratings []entities.Rating
+}
+
+func (s *ratingsStub) GetUserRatings(context.Context, int) ([]entities.Rating, error) {
+	return s.ratings, nil
+}
+
+func (s *ratingsStub) DeleteUserRatings(context.Context, int) error {
+	*s.calls = append(*s.calls, "ratings")
+	s.ratings = nil
+	return nil
+}
+
+type reviewsStub struct {
+	repositories.Reviews
+
+	calls *calls
+}
+
+func (s *reviewsStub) DeleteUserReviews(context.Context, int) error {
+	*s.calls = append(*s.calls, "reviews")
+	return nil
+}
+
+type moviesStub struct {
+	repositories.Movies
+
+	calls    *calls
+	movieIDs []int
+}
+
+func (s *moviesStub) RecomputeVotes(_ context.Context, movieIDs []int) error {
+	*s.calls = append(*s.calls, "votes")
+	s.movieIDs = movieIDs
+	return nil
+}
+
+type authStub struct {
+	clients.Auth
+
+	calls     *calls
+	deleteErr error
+}
+
+func (s *authStub) RevokeSessions(context.Context, int) error {
+	*s.calls = append(*s.calls, "sessions")
+	return nil
+}
+
+func (s *authStub) DeleteAccount(context.Context, int) error {
+	*s.calls = append(*s.calls, "account")
+	return s.deleteErr
+}
+
+type workerFixture struct {
+	worker    *Worker
+	deletions *deletionsStub
+	ratings   *ratingsStub
+	movies    *moviesStub
+	auth      *authStub
+	calls     *calls
+	now       *time.Time
+}
+
+func newWorkerFixture(cfg Config) workerFixture {
+	now := time.Unix(600_000, 0)
+	clock := func() time.Time { return now }
+
+	f := workerFixture{calls: &calls{}, now: &now}
+	f.deletions = &deletionsStub{jobs: map[int]entities.AccountDeletion{
+		7: {UserID: 7, Step: entities.AccountDeletionPending},
+	}, now: clock}
+	f.ratings = &ratingsStub{calls: f.calls, ratings: []entities.Rating{{UserID: 7, MovieID: 1}}}
+	f.movies = &moviesStub{calls: f.calls}
+	f.auth = &authStub{calls: f.calls}
+
+	f.worker = New(f.deletions, f.ratings, &reviewsStub{calls: f.calls}, f.movies, f.auth, cfg)
+	f.worker.now = clock
+	return f
+}
+
+func TestWorkerErasesAfterAccountDeletion(t *testing.T) {
+	f := newWorkerFixture(Config{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour})
+
+	// A rating written after the data steps ran, as the user logged in again
+	// before the account was gone.
+	f.worker.auth = &relogAuth{authStub: f.auth, ratings: f.ratings}
+
+	f.worker.processBatch(context.Background())
+
+	want := calls{"ratings", "reviews", "votes", "sessions", "account", "ratings", "reviews", "votes"}
+	if !slices.Equal(*f.calls, want) {
+		t.Errorf("got calls %v, want %v", *f.calls, want)
+	}
+
+	if job := f.deletions.jobs[7]; job.Step != entities.AccountDeletionDone {
+		t.Errorf("got step %s, want %s", job.Step, entities.AccountDeletionDone)
+	}
+	if !slices.Equal(f.movies.movieIDs, []int{1, 2}) {
+		t.Errorf("recomputed votes of %v, want the movies of both passes", f.movies.movieIDs)
+	}
+}
+
+// relogAuth rates another movie on behalf of the user while the sessions are
+// revoked.
+type relogAuth struct {
+	*authStub
+
+	ratings *ratingsStub
+}
+
+func (a *relogAuth) RevokeSessions(ctx context.Context, userID int) error {
+	a.ratings.ratings = append(a.ratings.ratings, entities.Rating{UserID: userID, MovieID: 2})
+	return a.authStub.RevokeSessions(ctx, userID)
+}
+
+func TestWorkerBacksOffAndGivesUp(t *testing.T) {
+	f := newWorkerFixture(Config{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour})
+	f.auth.deleteErr = errors.New("connection refused")
+
+	// advance is how far the clock moves before each tick, wantAttempts the
+	// number of account deletions tried once the tick ran.
+	ticks := []struct {
+		advance      time.Duration
+		wantAttempts int
+	}{
+		{0, 1},
+		{30 * time.Second, 1},
+		{30 * time.Second, 2},
+		{time.Minute, 2},
+		{time.Minute, 3},
+		{time.Hour, 3},
+	}
+
+	for i, tick := range ticks {
+		*f.now = f.now.Add(tick.advance)
+		f.worker.processBatch(context.Background())
+
+		attempts := 0
+		for _, call := range *f.calls {
+			if call == "account" {
+				attempts++
+			}
+		}
+		if attempts != tick.wantAttempts {
+			t.Fatalf("tick %d: got %d attempts, want %d", i, attempts, tick.wantAttempts)
+		}
+	}
+
+	job := f.deletions.jobs[7]
+	if job.Step != entities.AccountDeletionFailed || job.Attempts != 3 {
+		t.Errorf("got step %s after %d attempts, want %s after 3", job.Step, job.Attempts, entities.AccountDeletionFailed)
+	}
+	if want := "votes_recomputed: connection refused"; job.LastError != want {
+		t.Errorf("got last error %q, want %q", job.LastError, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	w := New(nil, nil, nil, nil, nil, Config{Backoff: time.Minute, MaxBackoff: 5 * time.Minute})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{4, 5 * time.Minute},
+		{40, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := w.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -2,22 +2,39 @@ package poll
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
 	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Poller struct {
-	subcriber repositories.MovieSubscriber
-	movies    repositories.Movies
+	subcriber     repositories.MovieSubscriber
+	movies        repositories.Movies
+	invalidations repositories.CacheInvalidations
+	tracer        trace.Tracer
+
+	lastBatch atomic.Int64
 }
 
-func New(subscriber repositories.MovieSubscriber, movies repositories.Movies) *Poller {
-	return &Poller{
-		subcriber: subscriber,
-		movies:    movies,
+func New(subscriber repositories.MovieSubscriber, movies repositories.Movies, invalidations repositories.CacheInvalidations) *Poller {
+	p := &Poller{
+		subcriber:     subscriber,
+		movies:        movies,
+		invalidations: invalidations,
+		tracer:        otel.Tracer("gateway.poll"),
 	}
+	p.lastBatch.Store(time.Now().UnixNano())
+
+	return p
 }
 
 func (p *Poller) Poll(ctx context.Context) {
@@ -27,13 +44,36 @@ func (p *Poller) Poll(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case batch := <-moviesCh:
-			if err := p.movies.InsertMovies(ctx, batch); err != nil {
-				ctxlogrus.Extract(ctx).Warnf("unable to save extracted movies: %s", err.Error())
-				continue
-			}
-
-			ctxlogrus.Extract(ctx).Infof("got %d movies", len(batch))
-			metrics.RecordBatchSize(ctx, len(batch))
+			p.lastBatch.Store(time.Now().UnixNano())
+			p.save(tracing.Extract(context.WithoutCancel(ctx), batch.Trace), batch.Movies)
 		}
 	}
 }
+
+// LastBatch reports when the latest batch arrived, or when the poller was
+// created if none has yet.
+func (p *Poller) LastBatch() time.Time {
+	return time.Unix(0, p.lastBatch.Load())
+}
+
+// save is not interrupted by shutdown, the poller stops once the batch it
+// holds is stored. The repository timeout still bounds it.
+func (p *Poller) save(ctx context.Context, batch []entities.Movie) {
+	ctx, span := p.tracer.Start(ctx, "poll.SaveBatch", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+	span.SetAttributes(attribute.Int("batch.size", len(batch)))
+
+	if err := p.movies.InsertMovies(ctx, batch); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to save extracted movies: %s", err.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	if err := p.invalidations.Publish(ctx, entities.NewCatalogInvalidation(batch)); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to publish cache invalidation: %s", err.Error())
+	}
+
+	ctxlogrus.Extract(ctx).Infof("got %d movies", len(batch))
+	metrics.RecordBatchSize(ctx, len(batch))
+}
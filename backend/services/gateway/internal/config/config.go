@@ -2,9 +2,23 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/application/deletion"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/batch"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/cache"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/auth"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/oidc"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
+	cacheinvalidations "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/cache_invalidations"
 	moviesubscriber "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/movie_subscriber"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/trending"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/graph"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/health"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
 	"github.com/joho/godotenv"
 )
 
@@ -12,9 +26,26 @@ type Config struct {
 	Subscriber moviesubscriber.Config
 	Database   DatabaseConfig
 	Redis      RedisConfig
-	Auth       AuthConfig
+	Auth       auth.Config
+	OIDC       oidc.Config
 	Collector  CollectorConfig
+	Metrics    metrics.Config
 	Clickhouse ClickhouseConfig
+	RateLimit  RateLimitConfig
+	Cache      cache.Config
+	HTTPCache  HTTPCacheConfig
+	OpenAPI    OpenAPIConfig
+	API        APIConfig
+	GraphQL    graph.Limits
+
+	CacheInvalidations cacheinvalidations.Config
+	Trending           trending.Config
+
+	AccountDeletion deletion.Config
+	Admin           AdminConfig
+	Shutdown        ShutdownConfig
+	Health          HealthConfig
+	Startup         health.BackoffConfig
 }
 
 type DatabaseConfig struct {
@@ -28,15 +59,12 @@ type DatabaseConfig struct {
 }
 
 type RedisConfig struct {
-	Addr string
-}
-
-type AuthConfig struct {
-	Host    string
+	Addr    string
 	Timeout time.Duration
 }
 
 type CollectorConfig struct {
+	// Addr receives the traces and, with the otlp exporter, the metrics.
 	Addr string
 }
 
@@ -47,7 +75,58 @@ type ClickhouseConfig struct {
 	Password string
 	Name     string
 
+	Timeout          time.Duration
+	AnalyticsTimeout time.Duration
+	RequestLogs      batch.Config
+	Events           batch.Config
+}
+
+type ShutdownConfig struct {
+	HTTPTimeout       time.Duration
+	WorkerTimeout     time.Duration
+	RequestLogTimeout time.Duration
+	TelemetryTimeout  time.Duration
+}
+
+// AdminConfig.Timeout applies to the one-off CLI commands, which touch the
+// whole catalog and need a far longer timeout than requests do. UserIDs are
+// allowed to call the /admin endpoints.
+type AdminConfig struct {
 	Timeout time.Duration
+	UserIDs []int
+}
+
+type HealthConfig struct {
+	Timeout          time.Duration
+	MaxSubscriberLag time.Duration
+}
+
+type HTTPCacheConfig struct {
+	Default string
+	Routes  map[string]string
+}
+
+type OpenAPIConfig struct {
+	// ValidateResponses checks every response against the spec, meant for
+	// tests and staging as it buffers responses.
+	ValidateResponses bool
+}
+
+type APIConfig struct {
+	// The unversioned /api routes serve the first version for clients that
+	// predate /api/v1 and announce these dates in their deprecation headers.
+	LegacyDeprecatedAt time.Time
+	LegacySunset       time.Time
+}
+
+type RateLimitConfig struct {
+	Default entities.RateLimitPolicy
+	Routes  map[string]entities.RateLimitPolicy
+	Login   entities.LockoutPolicy
+
+	// Client is the budget of a client IP over every route, checked before
+	// the request is authenticated.
+	Client entities.RateLimitPolicy
 }
 
 func MustLoad() Config {
@@ -69,18 +148,43 @@ func MustLoad() Config {
 		},
 
 		Redis: RedisConfig{
-			Addr: stringOrDefault("REDIS_ADDR", ""),
+			Addr:    stringOrDefault("REDIS_ADDR", ""),
+			Timeout: timeOrDefault("REDIS_TIMEOUT", time.Second),
 		},
 
-		Auth: AuthConfig{
+		Auth: auth.Config{
 			Host:    stringOrDefault("AUTH_HOST", ""),
 			Timeout: timeOrDefault("AUTH_TIMEOUT", 5*time.Second),
+
+			JWKSRefreshInterval: intervalOrDefault("AUTH_JWKS_REFRESH_INTERVAL", 10*time.Minute),
+			AuthorizeCacheTTL:   timeOrDefault("AUTH_AUTHORIZE_CACHE_TTL", 30*time.Second),
+			AccessTokenTTL:      timeOrDefault("AUTH_ACCESS_TOKEN_TTL", 30*time.Minute),
+			LastSeenInterval:    timeOrDefault("AUTH_LAST_SEEN_INTERVAL", time.Minute),
+		},
+
+		OIDC: oidc.Config{
+			Providers:        oidcProviders(),
+			StateTTL:         timeOrDefault("OIDC_STATE_TTL", 10*time.Minute),
+			FrontendRedirect: stringOrDefault("OIDC_FRONTEND_REDIRECT", ""),
 		},
 
 		Collector: CollectorConfig{
 			Addr: stringOrDefault("COLLECTOR_ADDR", ""),
 		},
 
+		Metrics: metrics.Config{
+			MetricsConfig: telemetry.MetricsConfig{
+				Exporter:       stringOrDefault("METRICS_EXPORTER", telemetry.ExporterOTLP),
+				PrometheusAddr: stringOrDefault("METRICS_PROMETHEUS_ADDR", ":9464"),
+				Interval:       timeOrDefault("METRICS_INTERVAL", time.Minute),
+			},
+			SLO: metrics.SLOConfig{
+				Windows:    durationsOrDefault("METRICS_SLO_WINDOWS", []time.Duration{5 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour}),
+				Default:    objectiveOrDefault("METRICS_SLO_DEFAULT", metrics.Objective{Latency: 250 * time.Millisecond, Target: 0.99}),
+				Operations: objectivesOrDefault("METRICS_SLO_OPERATIONS", map[string]metrics.Objective{}),
+			},
+		},
+
 		Clickhouse: ClickhouseConfig{
 			Host:     stringOrDefault("CLICKHOUSE_HOST", ""),
 			Port:     stringOrDefault("CLICKHOUSE_PORT", ""),
@@ -88,9 +192,342 @@ func MustLoad() Config {
 			Password: stringOrDefault("CLICKHOUSE_PASSWORD", ""),
 			Name:     stringOrDefault("CLICKHOUSE_NAME", ""),
 
-			Timeout: timeOrDefault("CLICKHOUSE_TIMEOUT", 2*time.Second),
+			Timeout:          timeOrDefault("CLICKHOUSE_TIMEOUT", 2*time.Second),
+			AnalyticsTimeout: timeOrDefault("CLICKHOUSE_ANALYTICS_TIMEOUT", 30*time.Second),
+			RequestLogs: batch.Config{
+				QueueSize:     intOrDefault("CLICKHOUSE_REQUEST_LOG_QUEUE_SIZE", 10000),
+				BatchSize:     intOrDefault("CLICKHOUSE_REQUEST_LOG_BATCH_SIZE", 1000),
+				FlushInterval: intervalOrDefault("CLICKHOUSE_REQUEST_LOG_FLUSH_INTERVAL", time.Second),
+				Overflow:      stringOrDefault("CLICKHOUSE_REQUEST_LOG_OVERFLOW", batch.OverflowDrop),
+			},
+			Events: batch.Config{
+				QueueSize:     intOrDefault("CLICKHOUSE_EVENT_QUEUE_SIZE", 10000),
+				BatchSize:     intOrDefault("CLICKHOUSE_EVENT_BATCH_SIZE", 1000),
+				FlushInterval: intervalOrDefault("CLICKHOUSE_EVENT_FLUSH_INTERVAL", time.Second),
+				Overflow:      stringOrDefault("CLICKHOUSE_EVENT_OVERFLOW", batch.OverflowDrop),
+			},
+		},
+
+		RateLimit: RateLimitConfig{
+			Default: policyOrDefault("RATE_LIMIT_DEFAULT", entities.RateLimitPolicy{Limit: 120, Period: time.Minute, Burst: 40}),
+			Routes: routePoliciesOrDefault("RATE_LIMIT_ROUTES", map[string]entities.RateLimitPolicy{
+				"POST /api/login":    {Limit: 10, Period: time.Minute},
+				"POST /api/register": {Limit: 5, Period: time.Minute},
+				"POST /api/refresh":  {Limit: 20, Period: time.Minute},
+				"POST /api/rating":   {Limit: 30, Period: time.Minute},
+				"DELETE /api/rating": {Limit: 30, Period: time.Minute},
+			}),
+			Login: entities.LockoutPolicy{
+				MaxFailures: intOrDefault("LOGIN_LOCKOUT_MAX_FAILURES", 5),
+				Window:      timeOrDefault("LOGIN_LOCKOUT_WINDOW", 15*time.Minute),
+				Duration:    timeOrDefault("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			},
+			Client: policyOrDefault("RATE_LIMIT_CLIENT", entities.RateLimitPolicy{Limit: 600, Period: time.Minute, Burst: 200}),
+		},
+
+		Cache: cache.Config{
+			TTL:          timeOrDefault("CACHE_TTL", 10*time.Minute),
+			ListTTL:      timeOrDefault("CACHE_LIST_TTL", time.Minute),
+			LocalTTL:     timeOrDefault("CACHE_LOCAL_TTL", 30*time.Second),
+			LocalSize:    intOrDefault("CACHE_LOCAL_SIZE", 10000),
+			RedisTimeout: timeOrDefault("CACHE_REDIS_TIMEOUT", 100*time.Millisecond),
+		},
+
+		HTTPCache: HTTPCacheConfig{
+			Default: stringOrDefault("CACHE_CONTROL_DEFAULT", "private, no-cache"),
+			Routes: cacheControlRoutesOrDefault("CACHE_CONTROL_ROUTES", map[string]string{
+				"GET /api/movies/:id":        "public, max-age=60",
+				"GET /api/movies/popular":    "public, max-age=60",
+				"GET /api/movies/trending":   "public, max-age=60",
+				"GET /api/movies/search":     "public, max-age=30",
+				"GET /api/actors/:id":        "public, max-age=300",
+				"GET /api/actors/search":     "public, max-age=60",
+				"GET /api/reviews/:movie_id": "public, max-age=30",
+				"GET /api/users/:id":         "public, max-age=30",
+				"GET /api/me/export":         "private, no-store",
+				"GET /api/sessions":          "private, no-store",
+			}),
+		},
+
+		CacheInvalidations: cacheinvalidations.Config{
+			Channel: stringOrDefault("CACHE_INVALIDATION_CHANNEL", "cache_invalidations"),
+		},
+
+		Trending: trending.Config{
+			CacheTTL: timeOrDefault("TRENDING_CACHE_TTL", time.Minute),
+		},
+
+		OpenAPI: OpenAPIConfig{
+			ValidateResponses: boolOrDefault("OPENAPI_VALIDATE_RESPONSES", false),
+		},
+
+		GraphQL: graph.Limits{
+			MaxDepth:      intOrDefault("GRAPHQL_MAX_DEPTH", 6),
+			MaxComplexity: intOrDefault("GRAPHQL_MAX_COMPLEXITY", 1000),
+		},
+
+		API: APIConfig{
+			LegacyDeprecatedAt: dateOrDefault("API_LEGACY_DEPRECATED_AT", time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)),
+			LegacySunset:       dateOrDefault("API_LEGACY_SUNSET", time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)),
+		},
+
+		AccountDeletion: deletion.Config{
+			Interval:    intervalOrDefault("ACCOUNT_DELETION_INTERVAL", 30*time.Second),
+			MaxAttempts: intOrDefault("ACCOUNT_DELETION_MAX_ATTEMPTS", 10),
+			Backoff:     timeOrDefault("ACCOUNT_DELETION_BACKOFF", time.Minute),
+			MaxBackoff:  timeOrDefault("ACCOUNT_DELETION_MAX_BACKOFF", time.Hour),
+		},
+
+		Admin: AdminConfig{
+			Timeout: timeOrDefault("ADMIN_TIMEOUT", 30*time.Minute),
+			UserIDs: intsOrDefault("ADMIN_USER_IDS", nil),
+		},
+
+		Shutdown: ShutdownConfig{
+			HTTPTimeout:       timeOrDefault("SHUTDOWN_HTTP_TIMEOUT", 20*time.Second),
+			WorkerTimeout:     timeOrDefault("SHUTDOWN_WORKER_TIMEOUT", 15*time.Second),
+			RequestLogTimeout: timeOrDefault("SHUTDOWN_REQUEST_LOG_TIMEOUT", 5*time.Second),
+			TelemetryTimeout:  timeOrDefault("SHUTDOWN_TELEMETRY_TIMEOUT", 5*time.Second),
+		},
+
+		Health: HealthConfig{
+			Timeout:          timeOrDefault("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			MaxSubscriberLag: timeOrDefault("HEALTH_MAX_SUBSCRIBER_LAG", 10*time.Minute),
 		},
+
+		Startup: health.BackoffConfig{
+			Initial: timeOrDefault("STARTUP_BACKOFF_INITIAL", 500*time.Millisecond),
+			Max:     timeOrDefault("STARTUP_BACKOFF_MAX", 10*time.Second),
+			Timeout: timeOrDefault("STARTUP_TIMEOUT", 2*time.Minute),
+		},
+	}
+}
+
+// oidcProviders reads providers listed in OIDC_PROVIDERS, each configured by
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID and so on.
+func oidcProviders() []oidc.ProviderConfig {
+	var providers []oidc.ProviderConfig
+
+	for _, name := range strings.Split(stringOrDefault("OIDC_PROVIDERS", ""), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, oidc.ProviderConfig{
+			Name:         name,
+			Issuer:       stringOrDefault(prefix+"ISSUER", ""),
+			ClientID:     stringOrDefault(prefix+"CLIENT_ID", ""),
+			ClientSecret: stringOrDefault(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  stringOrDefault(prefix+"REDIRECT_URL", ""),
+			Scopes:       strings.Fields(stringOrDefault(prefix+"SCOPES", "")),
+		})
+	}
+
+	return providers
+}
+
+// policyOrDefault parses policies written as "limit/period" or
+// "limit/period/burst", e.g. "120/1m/40".
+func policyOrDefault(envName string, defaultValue entities.RateLimitPolicy) entities.RateLimitPolicy {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	policy, ok := parsePolicy(raw)
+	if !ok {
+		return defaultValue
+	}
+
+	return policy
+}
+
+// routePoliciesOrDefault parses comma separated "METHOD /path=policy" pairs.
+// Routes from the environment are merged over the defaults.
+func routePoliciesOrDefault(envName string, defaultValue map[string]entities.RateLimitPolicy) map[string]entities.RateLimitPolicy {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		route, rawPolicy, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		policy, ok := parsePolicy(rawPolicy)
+		if !ok {
+			continue
+		}
+
+		defaultValue[strings.TrimSpace(route)] = policy
+	}
+
+	return defaultValue
+}
+
+// cacheControlRoutesOrDefault parses semicolon separated "METHOD /path=policy"
+// pairs, policies themselves contain commas. Routes from the environment are
+// merged over the defaults.
+func cacheControlRoutesOrDefault(envName string, defaultValue map[string]string) map[string]string {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
 	}
+
+	for _, pair := range strings.Split(raw, ";") {
+		route, policy, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		defaultValue[strings.TrimSpace(route)] = strings.TrimSpace(policy)
+	}
+
+	return defaultValue
+}
+
+func parsePolicy(raw string) (entities.RateLimitPolicy, bool) {
+	parts := strings.Split(strings.TrimSpace(raw), "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return entities.RateLimitPolicy{}, false
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return entities.RateLimitPolicy{}, false
+	}
+
+	period, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return entities.RateLimitPolicy{}, false
+	}
+
+	policy := entities.RateLimitPolicy{Limit: limit, Period: period}
+	if len(parts) == 3 {
+		if policy.Burst, err = strconv.Atoi(parts[2]); err != nil {
+			return entities.RateLimitPolicy{}, false
+		}
+	}
+
+	return policy, true
+}
+
+// objectiveOrDefault parses objectives written as "latency/target percent",
+// e.g. "250ms/99.5".
+func objectiveOrDefault(envName string, defaultValue metrics.Objective) metrics.Objective {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	objective, ok := parseObjective(raw)
+	if !ok {
+		return defaultValue
+	}
+
+	return objective
+}
+
+// objectivesOrDefault parses comma separated "table.operation=objective"
+// pairs. Operations from the environment are merged over the defaults.
+func objectivesOrDefault(envName string, defaultValue map[string]metrics.Objective) map[string]metrics.Objective {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		operation, rawObjective, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		objective, ok := parseObjective(rawObjective)
+		if !ok {
+			continue
+		}
+
+		defaultValue[strings.TrimSpace(operation)] = objective
+	}
+
+	return defaultValue
+}
+
+func parseObjective(raw string) (metrics.Objective, bool) {
+	rawLatency, rawTarget, ok := strings.Cut(strings.TrimSpace(raw), "/")
+	if !ok {
+		return metrics.Objective{}, false
+	}
+
+	latency, err := time.ParseDuration(rawLatency)
+	if err != nil {
+		return metrics.Objective{}, false
+	}
+
+	target, err := strconv.ParseFloat(rawTarget, 64)
+	if err != nil || target <= 0 || target >= 100 {
+		return metrics.Objective{}, false
+	}
+
+	return metrics.Objective{Latency: latency, Target: target / 100}, true
+}
+
+// durationsOrDefault parses a comma separated list of durations, entries that
+// are not durations are skipped.
+func durationsOrDefault(envName string, defaultValue []time.Duration) []time.Duration {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	var values []time.Duration
+	for _, part := range strings.Split(raw, ",") {
+		value, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || value <= 0 {
+			continue
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// intsOrDefault parses a comma separated list of integers, entries that are
+// not integers are skipped.
+func intsOrDefault(envName string, defaultValue []int) []int {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	var values []int
+	for _, part := range strings.Split(raw, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func intOrDefault(envName string, defaultValue int) int {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
 }
 
 func timeOrDefault(envName string, defaultValue time.Duration) time.Duration {
@@ -107,6 +544,45 @@ func timeOrDefault(envName string, defaultValue time.Duration) time.Duration {
 	return value
 }
 
+// intervalOrDefault is timeOrDefault for the periods of tickers, which panic
+// on anything but a positive duration.
+func intervalOrDefault(envName string, defaultValue time.Duration) time.Duration {
+	value := timeOrDefault(envName, defaultValue)
+	if value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+func dateOrDefault(envName string, defaultValue time.Time) time.Time {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	value, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func boolOrDefault(envName string, defaultValue bool) bool {
+	raw, ok := os.LookupEnv(envName)
+	if !ok {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
 func stringOrDefault(envName, defaultValue string) string {
 	value, ok := os.LookupEnv(envName)
 	if !ok {
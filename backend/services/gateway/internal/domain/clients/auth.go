@@ -14,10 +14,31 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 )
 
+// RevokedSessionError is an ErrUnauthorized for which the auth service also
+// revoked the session the request belonged to.
+type RevokedSessionError struct {
+	SessionID string
+}
+
+func (e *RevokedSessionError) Error() string {
+	return "session " + e.SessionID + " was revoked"
+}
+
+func (e *RevokedSessionError) Unwrap() error {
+	return ErrUnauthorized
+}
+
 type Auth interface {
-	Login(ctx context.Context, base64 string) (int, string, error)
+	Login(ctx context.Context, base64 string, client entities.ClientInfo) (int, entities.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string, client entities.ClientInfo) (int, entities.Tokens, error)
+	ExternalLogin(ctx context.Context, identity entities.ExternalIdentity, linkUserID int, client entities.ClientInfo) (int, entities.Tokens, error)
 	Register(ctx context.Context, body []byte) error
 	Logout(ctx context.Context, token string) error
 	Authorize(ctx context.Context, token string) (entities.User, error)
-	Username(ctx context.Context, userID int) (string, error)
+	User(ctx context.Context, userID int) (entities.User, error)
+	Sessions(ctx context.Context, userID int) ([]entities.Session, error)
+	RevokeSession(ctx context.Context, userID int, sessionID string) error
+	RevokeSessions(ctx context.Context, userID int) error
+	TouchSession(ctx context.Context, sessionID string) error
+	DeleteAccount(ctx context.Context, userID int) error
 }
@@ -0,0 +1,12 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type OIDC interface {
+	AuthCodeURL(ctx context.Context, provider, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, provider, code, codeVerifier, nonce string) (entities.ExternalIdentity, error)
+}
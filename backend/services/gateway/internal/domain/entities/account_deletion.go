@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+type AccountDeletionStep string
+
+const (
+	AccountDeletionPending         AccountDeletionStep = "pending"
+	AccountDeletionRatingsDeleted  AccountDeletionStep = "ratings_deleted"
+	AccountDeletionReviewsDeleted  AccountDeletionStep = "reviews_deleted"
+	AccountDeletionVotesRecomputed AccountDeletionStep = "votes_recomputed"
+	AccountDeletionAccountDeleted  AccountDeletionStep = "account_deleted"
+	AccountDeletionDone            AccountDeletionStep = "done"
+	AccountDeletionFailed          AccountDeletionStep = "failed"
+)
+
+type AccountDeletion struct {
+	UserID    int                 `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Step      AccountDeletionStep `gorm:"index" json:"step"`
+	MovieIDs  []int               `gorm:"serializer:json" json:"-"`
+	Attempts  int                 `json:"attempts"`
+	LastError string              `json:"last_error,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+type PathLatency struct {
+	Path     string  `json:"path"`
+	Requests int     `json:"requests"`
+	P50Ms    float64 `json:"p50_ms"`
+	P95Ms    float64 `json:"p95_ms"`
+	P99Ms    float64 `json:"p99_ms"`
+}
+
+type StatusClassRate struct {
+	StatusClass string  `json:"status_class"`
+	Requests    int     `json:"requests"`
+	Rate        float64 `json:"rate"`
+}
+
+type UserRequests struct {
+	UserID   int `json:"user_id"`
+	Requests int `json:"requests"`
+}
+
+// DailyActiveUsers.Day is formatted as YYYY-MM-DD.
+type DailyActiveUsers struct {
+	Day   string `json:"day"`
+	Users int    `json:"users"`
+}
+
+type RequestBucket struct {
+	Time     time.Time `json:"time"`
+	Requests int       `json:"requests"`
+	Errors   int       `json:"errors"`
+	P95Ms    float64   `json:"p95_ms"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+type CacheInvalidation struct {
+	MovieIDs []int `json:"movie_ids"`
+	ActorIDs []int `json:"actor_ids"`
+	// Catalog is set when the set or order of movies may have changed, which
+	// makes every cached list stale.
+	Catalog bool `json:"catalog"`
+	// All drops everything, for bulk changes that are not worth listing.
+	All bool `json:"all"`
+}
+
+// NewCatalogInvalidation covers the movies of an upserted batch and their
+// actors.
+func NewCatalogInvalidation(batch []Movie) CacheInvalidation {
+	invalidation := CacheInvalidation{
+		MovieIDs: make([]int, 0, len(batch)),
+		Catalog:  true,
+	}
+
+	seen := make(map[int]struct{})
+	for _, movie := range batch {
+		invalidation.MovieIDs = append(invalidation.MovieIDs, movie.ID)
+
+		for _, actor := range movie.Actors {
+			if _, ok := seen[actor.ID]; ok {
+				continue
+			}
+			seen[actor.ID] = struct{}{}
+			invalidation.ActorIDs = append(invalidation.ActorIDs, actor.ID)
+		}
+	}
+
+	return invalidation
+}
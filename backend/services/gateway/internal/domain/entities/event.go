@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+const (
+	EventMovieViewed      = "movie_viewed"
+	EventSearchPerformed  = "search_performed"
+	EventStreamLinkOpened = "stream_link_opened"
+	EventRatingSubmitted  = "rating_submitted"
+)
+
+// Event is a product analytics event. Fields that do not apply to its type
+// are left zero, UserID is zero for anonymous requests.
+type Event struct {
+	Timestamp   time.Time
+	Type        string
+	UserID      int
+	MovieID     int
+	Query       string
+	ResultCount int
+	Rating      float32
+	TraceID     string
+}
@@ -0,0 +1,15 @@
+package entities
+
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	Email    string
+	Username string
+}
+
+type LoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	LinkUserID   int    `json:"link_user_id"`
+}
@@ -17,4 +17,12 @@ type Movie struct {
 	Revenue               int       `json:"revenue"`
 	Genres                []Genre   `gorm:"many2many:movie_genres;constraint:OnDelete:CASCADE;" json:"genres"`
 	Actors                []Actor   `gorm:"many2many:movie_actors;constraint:OnDelete:CASCADE;" json:"actors"`
+	UpdatedAt             time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// MovieBatch is a batch published by the etl service. Trace holds the
+// publisher's trace context so storing the batch continues the same trace.
+type MovieBatch struct {
+	Movies []Movie
+	Trace  map[string]string
 }
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+type RateLimitPolicy struct {
+	Limit  int
+	Period time.Duration
+	Burst  int
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+type LockoutPolicy struct {
+	MaxFailures int
+	Window      time.Duration
+	Duration    time.Duration
+}
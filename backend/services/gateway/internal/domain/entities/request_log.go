@@ -3,13 +3,13 @@ package entities
 import "time"
 
 type RequestLog struct {
-	TraceID        string    `gorm:"primaryKey;autoIncrement:false"`
-	Timestamp      time.Time `gorm:"index"`
-	Method         string
-	Path           string
-	NormalizedPath string `gorm:"index"`
-	StatusCode     int
-	StatusClass    string `gorm:"index"`
-	DurationMs     int
-	UserID         int `gorm:"index"`
+	TraceID        string    `gorm:"primaryKey;autoIncrement:false" json:"trace_id"`
+	Timestamp      time.Time `gorm:"index" json:"timestamp"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	NormalizedPath string    `gorm:"index" json:"normalized_path"`
+	StatusCode     int       `json:"status_code"`
+	StatusClass    string    `gorm:"index" json:"status_class"`
+	DurationMs     int       `json:"duration_ms"`
+	UserID         int       `gorm:"index" json:"user_id"`
 }
@@ -1,9 +1,12 @@
 package entities
 
+import "time"
+
 type Review struct {
-	UserID  int    `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
-	MovieID int    `gorm:"primaryKey;autoIncrement:false" json:"movie_id"`
-	Liked   bool   `json:"liked"`
-	Title   string `gorm:"size:255" json:"title"`
-	Text    string `gorm:"type:text" json:"text"`
+	UserID    int       `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	MovieID   int       `gorm:"primaryKey;autoIncrement:false" json:"movie_id"`
+	Liked     bool      `json:"liked"`
+	Title     string    `gorm:"size:255" json:"title"`
+	Text      string    `gorm:"type:text" json:"text"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+type Session struct {
+	ID        string    `json:"id"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	LastSeen  time.Time `json:"last_seen"`
+	Current   bool      `json:"current"`
+}
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type ClientInfo struct {
+	Device string
+	IP     string
+}
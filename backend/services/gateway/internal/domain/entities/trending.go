@@ -0,0 +1,23 @@
+package entities
+
+type TrendingWindow string
+
+const (
+	TrendingHour TrendingWindow = "hour"
+	TrendingDay  TrendingWindow = "day"
+	TrendingWeek TrendingWindow = "week"
+)
+
+// Activity weights, the more effort an action takes the more it says about
+// interest in the movie.
+const (
+	ActivityView   = 1.0
+	ActivityStream = 2.0
+	ActivityRating = 3.0
+	ActivityReview = 4.0
+)
+
+type MovieScore struct {
+	MovieID int
+	Score   float64
+}
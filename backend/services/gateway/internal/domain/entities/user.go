@@ -1,6 +1,10 @@
 package entities
 
+import "time"
+
 type User struct {
-	ID       int
-	Username string
+	ID        int
+	Username  string
+	SessionID string
+	UpdatedAt time.Time
 }
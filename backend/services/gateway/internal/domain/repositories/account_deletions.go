@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type AccountDeletions interface {
+	Create(ctx context.Context, deletion entities.AccountDeletion) (entities.AccountDeletion, error)
+	GetUnfinished(ctx context.Context, limit int) ([]entities.AccountDeletion, error)
+	Update(ctx context.Context, deletion entities.AccountDeletion) error
+}
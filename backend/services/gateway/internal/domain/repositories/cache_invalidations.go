@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type CacheInvalidations interface {
+	Publish(ctx context.Context, invalidation entities.CacheInvalidation) error
+	Subscribe(ctx context.Context) <-chan entities.CacheInvalidation
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type Events interface {
+	// Record may store the event asynchronously.
+	Record(ctx context.Context, event entities.Event) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type LoginStates interface {
+	Save(ctx context.Context, state string, loginState entities.LoginState, ttl time.Duration) error
+	Pop(ctx context.Context, state string) (entities.LoginState, error)
+}
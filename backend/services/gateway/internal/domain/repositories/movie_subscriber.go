@@ -7,5 +7,5 @@ import (
 )
 
 type MovieSubscriber interface {
-	Subscribe(ctx context.Context) <-chan []entities.Movie
+	Subscribe(ctx context.Context) <-chan entities.MovieBatch
 }
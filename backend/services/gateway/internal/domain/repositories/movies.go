@@ -8,9 +8,16 @@ import (
 
 type Movies interface {
 	GetByID(ctx context.Context, id int) (entities.Movie, error)
+	GetByIDs(ctx context.Context, ids []int) ([]entities.Movie, error)
+	GetGenresByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Genre, error)
+	GetActorsByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Actor, error)
+	GetIDsByActorIDs(ctx context.Context, actorIDs []int) (map[int][]int, error)
 	SearchByTitle(ctx context.Context, title string) ([]entities.Movie, error)
 	GetPopular(ctx context.Context, offset, limit int) ([]entities.Movie, error)
+	List(ctx context.Context, afterID, limit int) ([]entities.Movie, error)
 	InsertMovies(ctx context.Context, movies []entities.Movie) error
 	AddVote(ctx context.Context, movieID int, newValue float32) error
 	DeleteVote(ctx context.Context, movieID int, oldValue float32) error
+	RecomputeVotes(ctx context.Context, movieIDs []int) error
+	RecomputeAllVotes(ctx context.Context) error
 }
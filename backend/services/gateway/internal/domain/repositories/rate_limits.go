@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type RateLimits interface {
+	Allow(ctx context.Context, key string, policy entities.RateLimitPolicy) (entities.RateLimitResult, error)
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	RegisterFailure(ctx context.Context, key string, policy entities.LockoutPolicy) (time.Duration, error)
+	ResetFailures(ctx context.Context, key string) error
+}
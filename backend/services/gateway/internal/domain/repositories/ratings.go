@@ -11,4 +11,7 @@ type Ratings interface {
 	DeleteRating(ctx context.Context, userID, movieID int) (float32, error)
 	GetUserRatings(ctx context.Context, userID int) ([]entities.Rating, error)
 	GetMovieRatings(ctx context.Context, movieID int) ([]entities.Rating, error)
+	GetByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Rating, error)
+	GetByUserIDs(ctx context.Context, userIDs []int) (map[int][]entities.Rating, error)
+	DeleteUserRatings(ctx context.Context, userID int) error
 }
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+// RequestLogAnalytics aggregates request logs written in [from, to).
+type RequestLogAnalytics interface {
+	Latency(ctx context.Context, from, to time.Time) ([]entities.PathLatency, error)
+	ErrorRates(ctx context.Context, from, to time.Time) ([]entities.StatusClassRate, error)
+	TopUsers(ctx context.Context, from, to time.Time, limit int) ([]entities.UserRequests, error)
+	DailyActiveUsers(ctx context.Context, from, to time.Time) ([]entities.DailyActiveUsers, error)
+	// TimeSeries only counts requests to path unless it is empty.
+	TimeSeries(ctx context.Context, from, to time.Time, bucket time.Duration, path string) ([]entities.RequestBucket, error)
+}
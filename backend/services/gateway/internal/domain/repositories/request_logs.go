@@ -8,4 +8,5 @@ import (
 
 type RequestLogs interface {
 	Insert(ctx context.Context, request *entities.RequestLog) error
+	GetByUserID(ctx context.Context, userID int) ([]entities.RequestLog, error)
 }
@@ -9,6 +9,9 @@ import (
 type Reviews interface {
 	GetByMovieID(ctx context.Context, movieID int) ([]entities.Review, error)
 	GetByUserID(ctx context.Context, userID int) ([]entities.Review, error)
+	GetByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Review, error)
+	GetByUserIDs(ctx context.Context, userIDs []int) (map[int][]entities.Review, error)
 	CreateOrUpdateReview(ctx context.Context, review entities.Review) error
 	DeleteReview(ctx context.Context, userID, movieID int) error
+	DeleteUserReviews(ctx context.Context, userID int) error
 }
@@ -0,0 +1,7 @@
+package repositories
+
+import "context"
+
+type SearchIndexes interface {
+	Reindex(ctx context.Context) error
+}
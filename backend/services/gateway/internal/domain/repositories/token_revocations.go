@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type TokenRevocations interface {
+	Revoke(ctx context.Context, token string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, token string) (bool, error)
+	TrackedSince(ctx context.Context) (time.Time, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+)
+
+type Trending interface {
+	Record(ctx context.Context, movieID int, weight float64) error
+	// Top returns the highest scored movies of the window, recent activity
+	// counting more than older one.
+	Top(ctx context.Context, window entities.TrendingWindow, limit int) ([]entities.MovieScore, error)
+}
@@ -0,0 +1,18 @@
+package batch
+
+import "time"
+
+const (
+	OverflowDrop  = "drop"
+	OverflowBlock = "block"
+)
+
+type Config struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+
+	// Overflow decides what Push does when the queue is full: "drop" loses
+	// the item, "block" makes the caller wait for room.
+	Overflow string
+}
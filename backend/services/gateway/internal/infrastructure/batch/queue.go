@@ -0,0 +1,102 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+var ErrDropped = errors.New("queue is full, item dropped")
+
+// Queue collects items and hands them to flush in batches, either once a batch
+// fills up or when the flush interval passes. Name labels its metrics.
+type Queue[T any] struct {
+	name  string
+	cfg   Config
+	flush func(ctx context.Context, items []T) error
+
+	items chan T
+}
+
+func New[T any](name string, cfg Config, flush func(ctx context.Context, items []T) error) *Queue[T] {
+	return &Queue[T]{
+		name:  name,
+		cfg:   cfg,
+		flush: flush,
+		items: make(chan T, max(cfg.QueueSize, 1)),
+	}
+}
+
+func (q *Queue[T]) Push(ctx context.Context, item T) error {
+	if q.cfg.Overflow == OverflowBlock {
+		select {
+		case q.items <- item:
+			return nil
+		case <-ctx.Done():
+			metrics.RecordQueueItems(ctx, q.name, "dropped", 1)
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case q.items <- item:
+		return nil
+	default:
+		metrics.RecordQueueItems(ctx, q.name, "dropped", 1)
+		return ErrDropped
+	}
+}
+
+// Run flushes until ctx is cancelled, then writes whatever is still queued
+// and returns, so it should be stopped after everything that pushes to it.
+func (q *Queue[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]T, 0, q.cfg.BatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := q.flush(ctx, batch); err != nil {
+			ctxlogrus.Extract(ctx).Warnf("unable to flush %d %s: %s", len(batch), q.name, err.Error())
+			metrics.RecordQueueItems(ctx, q.name, "failed", len(batch))
+		} else {
+			metrics.RecordQueueItems(ctx, q.name, "written", len(batch))
+		}
+
+		batch = make([]T, 0, q.cfg.BatchSize)
+	}
+
+	for {
+		select {
+		case item := <-q.items:
+			batch = append(batch, item)
+			if len(batch) >= q.cfg.BatchSize {
+				flush(ctx)
+			}
+
+		case <-ticker.C:
+			flush(ctx)
+
+		case <-ctx.Done():
+			ctx = context.WithoutCancel(ctx)
+			for {
+				select {
+				case item := <-q.items:
+					batch = append(batch, item)
+					if len(batch) >= q.cfg.BatchSize {
+						flush(ctx)
+					}
+				default:
+					flush(ctx)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	keyPrefix = "cache:"
+
+	popularMoviesPrefix = keyPrefix + "movies:popular:"
+)
+
+// Cache is a read-through cache with an in-process LRU in front of Redis.
+// Concurrent misses of the same key are collapsed into a single load. Redis
+// errors are logged and treated as misses, the cache never fails a read that
+// the underlying store could serve.
+type Cache struct {
+	client *redis.Client
+	local  *lru
+	group  singleflight.Group
+
+	// generation is bumped on every invalidation, loads that started before
+	// it changed do not store their result.
+	generation atomic.Uint64
+
+	cfg Config
+}
+
+func New(client *redis.Client, cfg Config) *Cache {
+	return &Cache{
+		client: client,
+		local:  newLRU(cfg.LocalSize),
+		cfg:    cfg,
+	}
+}
+
+func (c *Cache) TTL() time.Duration {
+	return c.cfg.TTL
+}
+
+func (c *Cache) ListTTL() time.Duration {
+	return c.cfg.ListTTL
+}
+
+func Fetch[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	if value, ok := c.local.Get(key); ok {
+		metrics.RecordCacheLookup(ctx, "local", true)
+		return value.(T), nil
+	}
+
+	value, err, _ := c.group.Do(key, func() (any, error) {
+		// The load is shared between callers, one of them going away must not
+		// fail the others.
+		ctx := context.WithoutCancel(ctx)
+		generation := c.generation.Load()
+
+		var value T
+		if c.getRemote(ctx, key, &value) {
+			metrics.RecordCacheLookup(ctx, "redis", true)
+			c.storeLocal(key, value, ttl, generation)
+			return value, nil
+		}
+		metrics.RecordCacheLookup(ctx, "redis", false)
+
+		value, err := load(ctx)
+		if err != nil {
+			return value, err
+		}
+
+		if c.generation.Load() == generation {
+			c.setRemote(ctx, key, value, ttl)
+			c.storeLocal(key, value, ttl, generation)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return value.(T), nil
+}
+
+func (c *Cache) Invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	c.generation.Add(1)
+	c.local.Delete(keys...)
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RedisTimeout)
+	defer cancel()
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to drop cached keys: %s", err.Error())
+	}
+}
+
+func (c *Cache) InvalidatePrefix(ctx context.Context, prefix string) {
+	c.generation.Add(1)
+	c.local.DeletePrefix(prefix)
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RedisTimeout)
+	defer cancel()
+
+	iter := c.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to scan cached keys: %s", err.Error())
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to drop cached keys: %s", err.Error())
+	}
+}
+
+func (c *Cache) InvalidateAll(ctx context.Context) {
+	c.InvalidatePrefix(ctx, keyPrefix)
+}
+
+// Listen applies invalidations published by any gateway replica until ctx is
+// done.
+func (c *Cache) Listen(ctx context.Context, invalidations <-chan entities.CacheInvalidation) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case invalidation := <-invalidations:
+			keys := make([]string, 0, len(invalidation.MovieIDs)+len(invalidation.ActorIDs))
+			for _, id := range invalidation.MovieIDs {
+				keys = append(keys, MovieKey(id))
+			}
+			for _, id := range invalidation.ActorIDs {
+				keys = append(keys, ActorKey(id))
+			}
+
+			if invalidation.All {
+				c.InvalidateAll(ctx)
+				continue
+			}
+
+			c.Invalidate(ctx, keys...)
+
+			if invalidation.Catalog {
+				c.InvalidatePrefix(ctx, popularMoviesPrefix)
+			}
+		}
+	}
+}
+
+func (c *Cache) storeLocal(key string, value any, ttl time.Duration, generation uint64) {
+	if c.generation.Load() != generation {
+		return
+	}
+
+	c.local.Set(key, value, min(ttl, c.cfg.LocalTTL))
+}
+
+func (c *Cache) getRemote(ctx context.Context, key string, dst any) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RedisTimeout)
+	defer cancel()
+
+	payload, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			ctxlogrus.Extract(ctx).Warnf("unable to read cached key %s: %s", key, err.Error())
+		}
+		return false
+	}
+
+	if err := json.Unmarshal(payload, dst); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to decode cached key %s: %s", key, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (c *Cache) setRemote(ctx context.Context, key string, value any, ttl time.Duration) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to encode cached key %s: %s", key, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RedisTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, key, payload, ttl).Err(); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to cache key %s: %s", key, err.Error())
+	}
+}
+
+func MovieKey(id int) string {
+	return fmt.Sprintf("%smovie:%d", keyPrefix, id)
+}
+
+func ActorKey(id int) string {
+	return fmt.Sprintf("%sactor:%d", keyPrefix, id)
+}
+
+func PopularMoviesKey(offset, limit int) string {
+	return fmt.Sprintf("%s%d:%d", popularMoviesPrefix, offset, limit)
+}
@@ -0,0 +1,13 @@
+package cache
+
+import "time"
+
+type Config struct {
+	TTL     time.Duration
+	ListTTL time.Duration
+
+	LocalTTL  time.Duration
+	LocalSize int
+
+	RedisTimeout time.Duration
+}
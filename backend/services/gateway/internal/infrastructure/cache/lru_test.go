@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// lruOp is one call on the cache: a set when ttl is given, a get otherwise,
+// after the clock moved forward by advance.
+type lruOp struct {
+	advance time.Duration
+	key     string
+	value   int
+	ttl     time.Duration
+}
+
+func TestLRU(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		ops  []lruOp
+		// want lists the keys still served at the end.
+		want []string
+	}{
+		{
+			name: "evicts the least recently set",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{key: "b", ttl: time.Minute},
+				{key: "c", ttl: time.Minute},
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "get makes an entry recent",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{key: "b", ttl: time.Minute},
+				{key: "a"},
+				{key: "c", ttl: time.Minute},
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name: "set of an existing key makes it recent",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{key: "b", ttl: time.Minute},
+				{key: "a", value: 1, ttl: time.Minute},
+				{key: "c", ttl: time.Minute},
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name: "expires after the ttl",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{key: "b", ttl: 2 * time.Minute},
+				{advance: time.Minute + time.Second},
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "still served at the ttl",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{advance: time.Minute},
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "set renews the ttl",
+			size: 2,
+			ops: []lruOp{
+				{key: "a", ttl: time.Minute},
+				{advance: 50 * time.Second, key: "a", ttl: time.Minute},
+				{advance: 50 * time.Second},
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "negative ttl is not stored",
+			size: 2,
+			ops:  []lruOp{{key: "a", ttl: -time.Second}},
+			want: nil,
+		},
+		{
+			name: "zero size stores nothing",
+			size: 0,
+			ops:  []lruOp{{key: "a", ttl: time.Minute}},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(600_000, 0)
+			l := newLRU(tt.size)
+			l.now = func() time.Time { return now }
+
+			for _, op := range tt.ops {
+				now = now.Add(op.advance)
+
+				switch {
+				case op.key == "":
+				case op.ttl != 0:
+					l.Set(op.key, op.value, op.ttl)
+				default:
+					l.Get(op.key)
+				}
+			}
+
+			var got []string
+			for _, key := range []string{"a", "b", "c"} {
+				if _, ok := l.Get(key); ok {
+					got = append(got, key)
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got keys %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLRUExpiredEntryIsRemoved(t *testing.T) {
+	now := time.Unix(600_000, 0)
+	l := newLRU(2)
+	l.now = func() time.Time { return now }
+
+	l.Set("a", 1, time.Second)
+	now = now.Add(2 * time.Second)
+
+	if _, ok := l.Get("a"); ok {
+		t.Fatal("got an expired entry")
+	}
+	if l.order.Len() != 0 || len(l.entries) != 0 {
+		t.Errorf("expired entry still held: %d in order, %d in entries", l.order.Len(), len(l.entries))
+	}
+}
+
+func TestLRUSetReplacesValue(t *testing.T) {
+	l := newLRU(2)
+	l.Set("a", 1, time.Minute)
+	l.Set("a", 2, time.Minute)
+
+	if value, _ := l.Get("a"); value != 2 {
+		t.Errorf("got %v, want 2", value)
+	}
+	if l.order.Len() != 1 {
+		t.Errorf("got %d entries, want 1", l.order.Len())
+	}
+}
+
+func TestLRUDelete(t *testing.T) {
+	l := newLRU(10)
+	for _, key := range []string{"movie:1", "movie:2", "movies:popular", "actor:1"} {
+		l.Set(key, key, time.Minute)
+	}
+
+	l.Delete("actor:1", "missing")
+	l.DeletePrefix("movie:")
+
+	var got []string
+	for _, key := range []string{"movie:1", "movie:2", "movies:popular", "actor:1"} {
+		if _, ok := l.Get(key); ok {
+			got = append(got, key)
+		}
+	}
+
+	if want := []string{"movies:popular"}; !slices.Equal(got, want) {
+		t.Errorf("got keys %v, want %v", got, want)
+	}
+	if l.order.Len() != len(l.entries) {
+		t.Errorf("order holds %d entries, the index %d", l.order.Len(), len(l.entries))
+	}
+}
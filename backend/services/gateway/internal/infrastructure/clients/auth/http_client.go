@@ -58,46 +58,104 @@ func (hc *httpClient) Register(ctx context.Context, body []byte) error {
 	}
 }
 
-func (hc *httpClient) Login(ctx context.Context, base64Creds string) (int, string, error) {
+func (hc *httpClient) Login(ctx context.Context, base64Creds string, client entities.ClientInfo) (int, entities.Tokens, error) {
 	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
 	defer cancel()
 
 	endpoint := "http://" + hc.host + "/login"
 	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
 	if err != nil {
-		return 0, "", fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 	}
 	req.Header.Set("Authorization", base64Creds)
+	setClientInfo(req, client)
 
+	return hc.doTokens(ctx, req, "/login")
+}
+
+func (hc *httpClient) Refresh(ctx context.Context, refreshToken string, client entities.ClientInfo) (int, entities.Tokens, error) {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	endpoint := "http://" + hc.host + "/refresh"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setClientInfo(req, client)
+
+	return hc.doTokens(ctx, req, "/refresh")
+}
+
+func (hc *httpClient) ExternalLogin(ctx context.Context, identity entities.ExternalIdentity, linkUserID int, client entities.ClientInfo) (int, entities.Tokens, error) {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]any{
+		"provider":     identity.Provider,
+		"subject":      identity.Subject,
+		"email":        identity.Email,
+		"username":     identity.Username,
+		"link_user_id": linkUserID,
+	})
+	if err != nil {
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	endpoint := "http://" + hc.host + "/external-login"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setClientInfo(req, client)
+
+	return hc.doTokens(ctx, req, "/external-login")
+}
+
+func (hc *httpClient) doTokens(ctx context.Context, req *http.Request, path string) (int, entities.Tokens, error) {
 	resp, err := tracing.DefaultHTTPClient().Do(req)
 	if err != nil {
-		return 0, "", fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, "", fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+		return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 	}
 
-	metrics.RecordStatusCodeFromAuth(ctx, resp.StatusCode, "/login")
+	metrics.RecordStatusCodeFromAuth(ctx, resp.StatusCode, path)
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var tokenResponse struct {
-			AccessToken string `json:"access_token"`
-			UserID      int    `json:"user_id"`
+			AccessToken  string `json:"access_token"`
+			RefreshToken string `json:"refresh_token"`
+			UserID       int    `json:"user_id"`
 		}
 		if err := json.Unmarshal(body, &tokenResponse); err != nil {
-			return 0, "", fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+			return 0, entities.Tokens{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 		}
-		return tokenResponse.UserID, tokenResponse.AccessToken, nil
-	case http.StatusBadRequest:
-		return 0, "", clients.ErrBadRequest
+		return tokenResponse.UserID, entities.Tokens{
+			AccessToken:  tokenResponse.AccessToken,
+			RefreshToken: tokenResponse.RefreshToken,
+		}, nil
+	case http.StatusBadRequest, http.StatusConflict:
+		return 0, entities.Tokens{}, clients.ErrBadRequest
 	case http.StatusUnauthorized:
-		return 0, "", clients.ErrUnauthorized
+		if sessionID := resp.Header.Get("X-Revoked-Session"); sessionID != "" {
+			return 0, entities.Tokens{}, &clients.RevokedSessionError{SessionID: sessionID}
+		}
+		return 0, entities.Tokens{}, clients.ErrUnauthorized
 	default:
-		return 0, "", clients.ErrUnexpected
+		return 0, entities.Tokens{}, clients.ErrUnexpected
 	}
 }
 
@@ -155,16 +213,18 @@ func (hc *httpClient) Authorize(ctx context.Context, token string) (entities.Use
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var authResponse struct {
-			UserID   int    `json:"user_id"`
-			Username string `json:"username"`
+			UserID    int    `json:"user_id"`
+			Username  string `json:"username"`
+			SessionID string `json:"session_id"`
 		}
 		if err := json.Unmarshal(body, &authResponse); err != nil {
 			return entities.User{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 		}
 
 		return entities.User{
-			ID:       authResponse.UserID,
-			Username: authResponse.Username,
+			ID:        authResponse.UserID,
+			Username:  authResponse.Username,
+			SessionID: authResponse.SessionID,
 		}, nil
 
 	case http.StatusUnauthorized:
@@ -174,44 +234,140 @@ func (hc *httpClient) Authorize(ctx context.Context, token string) (entities.Use
 	}
 }
 
-func (hc *httpClient) Username(ctx context.Context, userID int) (string, error) {
+func (hc *httpClient) User(ctx context.Context, userID int) (entities.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
 	defer cancel()
 
 	endpoint := fmt.Sprintf("http://%s/get-username/%d", hc.host, userID)
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return "", err
+		return entities.User{}, err
 	}
 
 	resp, err := tracing.DefaultHTTPClient().Do(req)
 	if err != nil {
-		return "", err
+		return entities.User{}, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return entities.User{}, err
 	}
 
 	metrics.RecordStatusCodeFromAuth(ctx, resp.StatusCode, "/username")
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		var usernameResponse struct {
-			Username string `json:"username"`
+		var userResponse struct {
+			Username  string    `json:"username"`
+			UpdatedAt time.Time `json:"updated_at"`
 		}
-		if err := json.Unmarshal(body, &usernameResponse); err != nil {
-			return "", fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+		if err := json.Unmarshal(body, &userResponse); err != nil {
+			return entities.User{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
 		}
 
-		if usernameResponse.Username == "" {
-			return "", clients.ErrNotFound
+		if userResponse.Username == "" {
+			return entities.User{}, clients.ErrNotFound
 		}
-		return usernameResponse.Username, nil
+		return entities.User{
+			ID:        userID,
+			Username:  userResponse.Username,
+			UpdatedAt: userResponse.UpdatedAt,
+		}, nil
 
 	default:
-		return "", clients.ErrUnexpected
+		return entities.User{}, clients.ErrUnexpected
+	}
+}
+
+func (hc *httpClient) Sessions(ctx context.Context, userID int) ([]entities.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("http://%s/sessions?user_id=%d", hc.host, userID)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	resp, err := tracing.DefaultHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	metrics.RecordStatusCodeFromAuth(ctx, resp.StatusCode, "/sessions")
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var sessions []entities.Session
+		if err := json.Unmarshal(body, &sessions); err != nil {
+			return nil, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+		}
+		return sessions, nil
+	default:
+		return nil, clients.ErrUnexpected
+	}
+}
+
+func (hc *httpClient) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	endpoint := fmt.Sprintf("http://%s/sessions/%s?user_id=%d", hc.host, url.PathEscape(sessionID), userID)
+	return hc.send(ctx, "DELETE", endpoint, "/sessions/{session_id}")
+}
+
+func (hc *httpClient) RevokeSessions(ctx context.Context, userID int) error {
+	endpoint := fmt.Sprintf("http://%s/sessions?user_id=%d", hc.host, userID)
+	return hc.send(ctx, "DELETE", endpoint, "/sessions")
+}
+
+func (hc *httpClient) TouchSession(ctx context.Context, sessionID string) error {
+	endpoint := fmt.Sprintf("http://%s/sessions/%s/seen", hc.host, url.PathEscape(sessionID))
+	return hc.send(ctx, "POST", endpoint, "/sessions/{session_id}/seen")
+}
+
+func (hc *httpClient) DeleteAccount(ctx context.Context, userID int) error {
+	endpoint := fmt.Sprintf("http://%s/users/%d", hc.host, userID)
+	return hc.send(ctx, "DELETE", endpoint, "/users/{user_id}")
+}
+
+func (hc *httpClient) send(ctx context.Context, method, endpoint, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	resp, err := tracing.DefaultHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+	defer resp.Body.Close()
+
+	metrics.RecordStatusCodeFromAuth(ctx, resp.StatusCode, path)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return clients.ErrNotFound
+	default:
+		return clients.ErrUnexpected
+	}
+}
+
+func setClientInfo(req *http.Request, client entities.ClientInfo) {
+	if client.Device != "" {
+		req.Header.Set("User-Agent", client.Device)
+	}
+	if client.IP != "" {
+		req.Header.Set("X-Forwarded-For", client.IP)
 	}
 }
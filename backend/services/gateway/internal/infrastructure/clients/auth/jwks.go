@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	errUnknownKey      = errors.New("unknown signing key")
+	errKeysUnavailable = errors.New("signing keys are unavailable")
+)
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// keySet keeps the public keys published by the auth service. Keys are
+// refreshed periodically and on demand when a token is signed by a key we
+// have not seen yet, which covers rotation on the auth side.
+type keySet struct {
+	endpoint string
+	timeout  time.Duration
+
+	minRefreshInterval time.Duration
+
+	// group collapses the on-demand refreshes of concurrent requests that
+	// miss the same rotated key into a single fetch.
+	group singleflight.Group
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+}
+
+func newKeySet(endpoint string, timeout, minRefreshInterval time.Duration) *keySet {
+	return &keySet{
+		endpoint:           endpoint,
+		timeout:            timeout,
+		minRefreshInterval: minRefreshInterval,
+		keys:               make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (ks *keySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, ok := ks.lookup(kid); ok {
+		return key, nil
+	}
+
+	keys, err := ks.refresh(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errKeysUnavailable, err.Error())
+	}
+
+	// A refresh skipped by the throttle leaves a set that may predate a
+	// rotation, so the key is only unknown for sure in a set just fetched.
+	if keys == nil {
+		if key, ok := ks.lookup(kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: %s was not found in keys fetched earlier", errKeysUnavailable, kid)
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+func (ks *keySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+func (ks *keySet) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ks.refresh(ctx, true)
+		}
+	}
+}
+
+// refresh returns the keys it fetched, or nil when an on-demand refresh was
+// skipped because the last one is too recent. Verifications keep using the
+// current keys while the fetch is in flight, the lock is only taken to swap
+// them.
+func (ks *keySet) refresh(ctx context.Context, force bool) (map[string]*rsa.PublicKey, error) {
+	// Callers that join the fetch must not lose it to the first one leaving.
+	ctx = context.WithoutCancel(ctx)
+
+	keys, err, _ := ks.group.Do("jwks", func() (any, error) {
+		ks.mu.Lock()
+		if !force && time.Since(ks.lastRefresh) < ks.minRefreshInterval {
+			ks.mu.Unlock()
+			return map[string]*rsa.PublicKey(nil), nil
+		}
+		ks.lastRefresh = time.Now()
+		ks.mu.Unlock()
+
+		keys, err := ks.fetch(ctx)
+		if err != nil {
+			return map[string]*rsa.PublicKey(nil), err
+		}
+
+		ks.mu.Lock()
+		ks.keys = keys
+		ks.mu.Unlock()
+
+		return keys, nil
+	})
+
+	return keys.(map[string]*rsa.PublicKey), err
+}
+
+func (ks *keySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, ks.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", ks.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tracing.DefaultHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint responded with %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, k := range document.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
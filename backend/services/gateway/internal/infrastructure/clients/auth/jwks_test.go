@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer publishes one key under the kid it is given, which can be
+// changed to simulate a rotation. Requests wait on block when it is set.
+type jwksServer struct {
+	mu    sync.Mutex
+	kid   string
+	key   *rsa.PublicKey
+	block chan struct{}
+
+	requests atomic.Int32
+	started  chan struct{}
+}
+
+func newJWKSServer(t *testing.T, kid string) (*jwksServer, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &jwksServer{kid: kid, key: &key.PublicKey, started: make(chan struct{}, 8)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.requests.Add(1)
+		s.started <- struct{}{}
+
+		s.mu.Lock()
+		kid, block := s.kid, s.block
+		s.mu.Unlock()
+
+		if block != nil {
+			<-block
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": kid,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	return s, server.URL
+}
+
+func (s *jwksServer) rotate(kid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kid = kid
+}
+
+func TestKeySetRotation(t *testing.T) {
+	tests := []struct {
+		name               string
+		minRefreshInterval time.Duration
+		kid                string
+		wantErr            error
+	}{
+		{"rotated key is fetched", 0, "next", nil},
+		{"forged key after a fetch", 0, "forged", errUnknownKey},
+		{"throttled refresh is not conclusive", time.Hour, "next", errKeysUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, url := newJWKSServer(t, "current")
+			ks := newKeySet(url, time.Second, tt.minRefreshInterval)
+			ctx := context.Background()
+
+			if _, err := ks.Key(ctx, "current"); err != nil {
+				t.Fatal(err)
+			}
+
+			server.rotate("next")
+
+			_, err := ks.Key(ctx, tt.kid)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("got %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeySetRefreshDoesNotBlockLookups(t *testing.T) {
+	server, url := newJWKSServer(t, "current")
+	ks := newKeySet(url, 5*time.Second, 0)
+	ctx := context.Background()
+
+	if _, err := ks.Key(ctx, "current"); err != nil {
+		t.Fatal(err)
+	}
+	<-server.started
+
+	block := make(chan struct{})
+	server.mu.Lock()
+	server.block = block
+	server.mu.Unlock()
+
+	refreshed := make(chan struct{})
+	go func() {
+		ks.refresh(ctx, true)
+		close(refreshed)
+	}()
+	<-server.started
+
+	looked := make(chan error, 1)
+	go func() {
+		_, err := ks.Key(ctx, "current")
+		looked <- err
+	}()
+
+	select {
+	case err := <-looked:
+		if err != nil {
+			t.Errorf("got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("lookup of a known key waited for the refresh")
+	}
+
+	close(block)
+	<-refreshed
+}
+
+func TestKeySetCollapsesRefreshes(t *testing.T) {
+	server, url := newJWKSServer(t, "current")
+	ks := newKeySet(url, 5*time.Second, 0)
+	ctx := context.Background()
+
+	block := make(chan struct{})
+	server.block = block
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ks.Key(ctx, "current")
+		}()
+	}
+
+	<-server.started
+	// Give the other lookups the time to join the fetch in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(block)
+	wg.Wait()
+
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("got %d fetches, want 1", got)
+	}
+}
@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+type Config struct {
+	Host    string
+	Timeout time.Duration
+
+	JWKSRefreshInterval time.Duration
+	AuthorizeCacheTTL   time.Duration
+	AccessTokenTTL      time.Duration
+	LastSeenInterval    time.Duration
+}
+
+type accessClaims struct {
+	Username  string `json:"username"`
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+type cachedUser struct {
+	user      entities.User
+	expiresAt time.Time
+}
+
+// jwtClient verifies access tokens locally against the auth service JWKS and
+// only goes to the remote /authorize endpoint when it cannot decide on its own.
+type jwtClient struct {
+	clients.Auth
+
+	keys        *keySet
+	revocations repositories.TokenRevocations
+	parser      *jwt.Parser
+
+	accessTokenTTL time.Duration
+
+	cacheTTL time.Duration
+	mu       sync.Mutex
+	cache    map[string]cachedUser
+
+	lastSeenInterval time.Duration
+	seenMu           sync.Mutex
+	seen             map[string]time.Time
+	lastSweep        time.Time
+}
+
+func NewJWTClient(ctx context.Context, remote clients.Auth, revocations repositories.TokenRevocations, cfg Config) clients.Auth {
+	keys := newKeySet("http://"+cfg.Host+"/.well-known/jwks.json", cfg.Timeout, cfg.JWKSRefreshInterval/10)
+	go keys.Run(ctx, cfg.JWKSRefreshInterval)
+
+	return &jwtClient{
+		Auth:           remote,
+		keys:           keys,
+		revocations:    revocations,
+		parser:         jwt.NewParser(jwt.WithValidMethods([]string{"RS256"}), jwt.WithExpirationRequired()),
+		accessTokenTTL: cfg.AccessTokenTTL,
+		cacheTTL:       cfg.AuthorizeCacheTTL,
+		cache:          make(map[string]cachedUser),
+
+		lastSeenInterval: cfg.LastSeenInterval,
+		seen:             make(map[string]time.Time),
+	}
+}
+
+func (jc *jwtClient) Authorize(ctx context.Context, token string) (entities.User, error) {
+	claims, err := jc.verify(ctx, token)
+	if err != nil {
+		if isInvalidToken(err) {
+			return entities.User{}, clients.ErrUnauthorized
+		}
+
+		ctxlogrus.Extract(ctx).Warnf("unable to verify token locally, falling back to remote authorize: %s", err.Error())
+		return jc.remoteAuthorize(ctx, token)
+	}
+
+	// Until the revocation cache has been tracking for a whole access token
+	// lifetime it may have lost revocations of tokens that are still valid.
+	since, err := jc.revocations.TrackedSince(ctx)
+	if err != nil {
+		ctxlogrus.Extract(ctx).Warnf("revocation cache is unavailable, falling back to remote authorize: %s", err.Error())
+		return jc.remoteAuthorize(ctx, token)
+	}
+	if time.Since(since) < jc.accessTokenTTL {
+		return jc.remoteAuthorize(ctx, token)
+	}
+
+	revoked, err := jc.isRevoked(ctx, token, claims.SessionID)
+	if err != nil {
+		ctxlogrus.Extract(ctx).Warnf("revocation cache is unavailable, falling back to remote authorize: %s", err.Error())
+		return jc.remoteAuthorize(ctx, token)
+	}
+	if revoked {
+		jc.forget(token)
+		return entities.User{}, clients.ErrUnauthorized
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return entities.User{}, clients.ErrUnauthorized
+	}
+
+	jc.touch(ctx, claims.SessionID)
+
+	return entities.User{
+		ID:        userID,
+		Username:  claims.Username,
+		SessionID: claims.SessionID,
+	}, nil
+}
+
+// touch keeps the last seen time of sessions fresh now that requests rarely
+// reach the auth service, at most once per interval and session.
+func (jc *jwtClient) touch(ctx context.Context, sessionID string) {
+	if sessionID == "" || jc.lastSeenInterval <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	jc.seenMu.Lock()
+	if last, ok := jc.seen[sessionID]; ok && now.Sub(last) < jc.lastSeenInterval {
+		jc.seenMu.Unlock()
+		return
+	}
+	if now.Sub(jc.lastSweep) >= jc.lastSeenInterval {
+		for id, last := range jc.seen {
+			if now.Sub(last) >= jc.lastSeenInterval {
+				delete(jc.seen, id)
+			}
+		}
+		jc.lastSweep = now
+	}
+	jc.seen[sessionID] = now
+	jc.seenMu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := jc.Auth.TouchSession(ctx, sessionID); err != nil && !errors.Is(err, clients.ErrNotFound) {
+			ctxlogrus.Extract(ctx).Warnf("unable to update session last seen time: %s", err.Error())
+		}
+	}()
+}
+
+// Refresh records sessions the auth service revoked on refresh token reuse,
+// their access tokens are still valid as far as local verification goes.
+func (jc *jwtClient) Refresh(ctx context.Context, refreshToken string, client entities.ClientInfo) (int, entities.Tokens, error) {
+	id, tokens, err := jc.Auth.Refresh(ctx, refreshToken, client)
+
+	var revoked *clients.RevokedSessionError
+	if errors.As(err, &revoked) {
+		jc.revokeSessions(ctx, revoked.SessionID)
+	}
+
+	return id, tokens, err
+}
+
+// RevokeSession marks the session as revoked locally as well, otherwise its
+// access token would keep passing local verification until it expires.
+func (jc *jwtClient) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	if err := jc.Auth.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	jc.revokeSessions(ctx, sessionID)
+	return nil
+}
+
+func (jc *jwtClient) RevokeSessions(ctx context.Context, userID int) error {
+	sessions, err := jc.Auth.Sessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := jc.Auth.RevokeSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+
+	jc.revokeSessions(ctx, ids...)
+	return nil
+}
+
+func (jc *jwtClient) revokeSessions(ctx context.Context, sessionIDs ...string) {
+	jc.mu.Lock()
+	for token, cached := range jc.cache {
+		for _, id := range sessionIDs {
+			if cached.user.SessionID == id {
+				delete(jc.cache, token)
+			}
+		}
+	}
+	jc.mu.Unlock()
+
+	for _, id := range sessionIDs {
+		if err := jc.revocations.Revoke(ctx, sessionRevocationKey(id), jc.accessTokenTTL); err != nil {
+			ctxlogrus.Extract(ctx).Warnf("unable to store session revocation: %s", err.Error())
+		}
+	}
+}
+
+func (jc *jwtClient) isRevoked(ctx context.Context, token, sessionID string) (bool, error) {
+	revoked, err := jc.revocations.IsRevoked(ctx, token)
+	if err != nil || revoked || sessionID == "" {
+		return revoked, err
+	}
+
+	return jc.revocations.IsRevoked(ctx, sessionRevocationKey(sessionID))
+}
+
+func (jc *jwtClient) Logout(ctx context.Context, token string) error {
+	if err := jc.Auth.Logout(ctx, token); err != nil {
+		return err
+	}
+
+	jc.forget(token)
+
+	ttl := jc.cacheTTL
+	if claims, err := jc.verify(ctx, token); err == nil && claims.ExpiresAt != nil {
+		ttl = time.Until(claims.ExpiresAt.Time)
+	}
+
+	if err := jc.revocations.Revoke(ctx, token, ttl); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to store token revocation: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (jc *jwtClient) verify(ctx context.Context, token string) (*accessClaims, error) {
+	claims := &accessClaims{}
+	_, err := jc.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return jc.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// remoteAuthorize asks the auth service and caches the answer for a short
+// time so a cold revocation cache or an unreachable JWKS endpoint does not
+// turn into one round trip per request.
+func (jc *jwtClient) remoteAuthorize(ctx context.Context, token string) (entities.User, error) {
+	jc.mu.Lock()
+	cached, ok := jc.cache[token]
+	jc.mu.Unlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.user, nil
+	}
+
+	user, err := jc.Auth.Authorize(ctx, token)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	now := time.Now()
+
+	jc.mu.Lock()
+	for k, v := range jc.cache {
+		if now.After(v.expiresAt) {
+			delete(jc.cache, k)
+		}
+	}
+	jc.cache[token] = cachedUser{user: user, expiresAt: now.Add(jc.cacheTTL)}
+	jc.mu.Unlock()
+
+	return user, nil
+}
+
+func (jc *jwtClient) forget(token string) {
+	jc.mu.Lock()
+	delete(jc.cache, token)
+	jc.mu.Unlock()
+}
+
+func isInvalidToken(err error) bool {
+	return errors.Is(err, jwt.ErrTokenMalformed) ||
+		errors.Is(err, jwt.ErrTokenSignatureInvalid) ||
+		errors.Is(err, jwt.ErrTokenExpired) ||
+		errors.Is(err, jwt.ErrTokenNotValidYet) ||
+		errors.Is(err, jwt.ErrTokenInvalidClaims) ||
+		errors.Is(err, errUnknownKey)
+}
+
+func sessionRevocationKey(sessionID string) string {
+	return "session:" + sessionID
+}
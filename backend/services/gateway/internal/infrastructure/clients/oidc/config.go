@@ -0,0 +1,23 @@
+package oidc
+
+import "time"
+
+type Config struct {
+	Providers []ProviderConfig
+
+	// How long a started login may wait for the provider callback.
+	StateTTL time.Duration
+
+	// Frontend page the callback redirects to with the issued tokens in the
+	// URL fragment. When empty the callback answers with JSON instead.
+	FrontendRedirect string
+}
+
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
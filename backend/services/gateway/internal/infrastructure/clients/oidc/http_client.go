@@ -0,0 +1,131 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+type provider struct {
+	cfg ProviderConfig
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+type httpClient struct {
+	providers map[string]*provider
+}
+
+func NewHTTPClient(cfg Config) clients.OIDC {
+	providers := make(map[string]*provider, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		providers[p.Name] = &provider{cfg: p}
+	}
+
+	return &httpClient{
+		providers: providers,
+	}
+}
+
+func (hc *httpClient) AuthCodeURL(ctx context.Context, name, state, nonce, codeVerifier string) (string, error) {
+	oauth, _, err := hc.discover(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	return oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (hc *httpClient) Exchange(ctx context.Context, name, code, codeVerifier, nonce string) (entities.ExternalIdentity, error) {
+	oauth, verifier, err := hc.discover(ctx, name)
+	if err != nil {
+		return entities.ExternalIdentity{}, err
+	}
+
+	ctx = oidc.ClientContext(ctx, tracing.DefaultHTTPClient())
+
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return entities.ExternalIdentity{}, fmt.Errorf("%w: %s", clients.ErrUnauthorized, err.Error())
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return entities.ExternalIdentity{}, fmt.Errorf("%w: provider returned no id_token", clients.ErrUnauthorized)
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return entities.ExternalIdentity{}, fmt.Errorf("%w: %s", clients.ErrUnauthorized, err.Error())
+	}
+
+	if idToken.Nonce != nonce {
+		return entities.ExternalIdentity{}, fmt.Errorf("%w: nonce mismatch", clients.ErrUnauthorized)
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return entities.ExternalIdentity{}, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	username := claims.PreferredUsername
+	if username == "" {
+		username = claims.Name
+	}
+
+	return entities.ExternalIdentity{
+		Provider: name,
+		Subject:  idToken.Subject,
+		Email:    claims.Email,
+		Username: username,
+	}, nil
+}
+
+// discover fetches the provider metadata on first use, so an unreachable
+// provider does not prevent the gateway from starting.
+func (hc *httpClient) discover(ctx context.Context, name string) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p, ok := hc.providers[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: unknown identity provider %s", clients.ErrNotFound, name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	discovered, err := oidc.NewProvider(oidc.ClientContext(ctx, tracing.DefaultHTTPClient()), p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", clients.ErrUnexpected, err.Error())
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"profile", "email"}
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     discovered.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, scopes...),
+	}
+	p.verifier = discovered.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth, p.verifier, nil
+}
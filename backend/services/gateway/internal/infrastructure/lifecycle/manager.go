@@ -0,0 +1,127 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+const defaultStopTimeout = 10 * time.Second
+
+var ErrStopTimeout = errors.New("component did not stop in time")
+
+// Component is a long running part of the process. Run blocks until its
+// context is cancelled or it fails. Stop, when set, is called before the
+// context is cancelled and should make Run finish its current unit of work.
+// Both get at most StopTimeout to return during shutdown.
+type Component struct {
+	Name string
+
+	Run  func(ctx context.Context) error
+	Stop func(ctx context.Context) error
+
+	StopTimeout time.Duration
+}
+
+type running struct {
+	Component
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager starts components in the order they were added and stops them in
+// reverse order, so a component added later may depend on the ones before it.
+type Manager struct {
+	components []Component
+}
+
+func New() *Manager {
+	return &Manager{}
+}
+
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+// Run starts every component and blocks until ctx is done or one of them
+// returns, then shuts everything down. It returns the error that caused the
+// shutdown, if any, joined with shutdown errors.
+func (m *Manager) Run(ctx context.Context) error {
+	logger := ctxlogrus.Extract(ctx)
+
+	// Components must outlive the signal context, they are cancelled one by
+	// one during shutdown.
+	base := context.WithoutCancel(ctx)
+
+	failed := make(chan error, len(m.components))
+	started := make([]*running, 0, len(m.components))
+	for _, c := range m.components {
+		runCtx, cancel := context.WithCancel(base)
+		r := &running{Component: c, cancel: cancel, done: make(chan struct{})}
+		started = append(started, r)
+
+		if c.Run == nil {
+			close(r.done)
+			continue
+		}
+
+		go func() {
+			defer close(r.done)
+
+			err := r.Run(runCtx)
+			if runCtx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = errors.New("stopped unexpectedly")
+			}
+			failed <- fmt.Errorf("%s: %w", r.Name, err)
+		}()
+	}
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down")
+	case cause = <-failed:
+		logger.Errorf("shutting down: %s", cause.Error())
+	}
+
+	errs := []error{cause}
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := stop(base, started[i]); err != nil {
+			logger.Warnf("unable to stop %s: %s", started[i].Name, err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", started[i].Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func stop(ctx context.Context, r *running) error {
+	timeout := r.StopTimeout
+	if timeout <= 0 {
+		timeout = defaultStopTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var err error
+	if r.Stop != nil {
+		err = r.Stop(ctx)
+	}
+
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return err
+	case <-ctx.Done():
+		return errors.Join(err, ErrStopTimeout)
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
+)
+
+type Config struct {
+	telemetry.MetricsConfig
+
+	SLO SLOConfig
+}
+
+// SLOConfig sets the latency objectives database operations are measured
+// against, burn rates are reported for every window.
+type SLOConfig struct {
+	Windows []time.Duration
+
+	// Default applies to operations without their own objective, a zero
+	// latency leaves them untracked.
+	Default Objective
+	// Operations are keyed by "table.operation", e.g. "movies.query".
+	Operations map[string]Objective
+}
+
+type Objective struct {
+	// Latency is the slowest an operation may be to still count as good,
+	// failed operations never do.
+	Latency time.Duration
+	// Target is the share of good operations, between 0 and 1 exclusive.
+	Target float64
+}
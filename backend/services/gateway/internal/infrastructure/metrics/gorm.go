@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+var (
+	poolsMu sync.Mutex
+	pools   = map[string]*sql.DB{}
+)
+
+// GORMPlugin records the latency and result of every statement by operation
+// and table, feeds the SLO burn rates with them and reports the connection
+// pool of the database.
+type GORMPlugin struct {
+	db string
+}
+
+// NewGORMPlugin takes the name the database is reported under.
+func NewGORMPlugin(db string) *GORMPlugin {
+	return &GORMPlugin{db: db}
+}
+
+func (p *GORMPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GORMPlugin) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	poolsMu.Lock()
+	pools[p.db] = sqlDB
+	poolsMu.Unlock()
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p *GORMPlugin) before(tx *gorm.DB) {
+	tx.InstanceSet(gormStartKey, time.Now())
+}
+
+func (p *GORMPlugin) after(operation string) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		// Raw SQL does not tell GORM which table it touches.
+		table := tx.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+
+		duration := time.Since(start)
+		result := RecordDBResult(tx.Statement.Context, operation, table, tx.Error)
+		recordDBDuration(tx.Statement.Context, p.db, operation, table, result, duration)
+
+		burnRates.Load().observe(sloKey{db: p.db, operation: operation, table: table}, duration, result == resultUnexpected)
+	}
+}
+
+func observedPools() map[string]sql.DBStats {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	stats := make(map[string]sql.DBStats, len(pools))
+	for name, db := range pools {
+		stats[name] = db.Stats()
+	}
+
+	return stats
+}
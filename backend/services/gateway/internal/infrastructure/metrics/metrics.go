@@ -6,47 +6,72 @@ import (
 	"time"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnightmarel0Ng/cinema/backend/pkg/go/telemetry"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/metric"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"gorm.io/gorm"
+)
+
+const (
+	resultOK           = "ok"
+	resultNotFound     = "not_found"
+	resultInvalidInput = "invalid_input"
+	resultUnexpected   = "unexpected"
 )
 
 var (
-	responseTimeHistogram metric.Float64Histogram
-	responseCounter       metric.Int64Counter
-	moviesCounter         metric.Int64Histogram
-	dbResultCounter       metric.Int64Counter
+	responseTimeHistogram   metric.Float64Histogram
+	activeRequestsCounter   metric.Int64UpDownCounter
+	requestSizeHistogram    metric.Int64Histogram
+	responseSizeHistogram   metric.Int64Histogram
+	responseCounter         metric.Int64Counter
+	apiRequestsCounter      metric.Int64Counter
+	moviesCounter           metric.Int64Histogram
+	dbResultCounter         metric.Int64Counter
+	dbDurationHistogram     metric.Float64Histogram
+	poolConnectionsGauge    metric.Int64ObservableGauge
+	poolMaxOpenGauge        metric.Int64ObservableGauge
+	poolWaitCounter         metric.Int64ObservableCounter
+	poolWaitDurationCounter metric.Float64ObservableCounter
+	sloBurnRateGauge        metric.Float64ObservableGauge
+	cacheLookupCounter      metric.Int64Counter
+	queueItemsCounter       metric.Int64Counter
 )
 
-func MustInit(ctx context.Context, collectorAddr string) func() {
-	exporter, err := otlpmetricgrpc.New(ctx,
-		otlpmetricgrpc.WithEndpoint(collectorAddr),
-		otlpmetricgrpc.WithInsecure(),
-	)
-	if err != nil {
-		panic(err)
-	}
+// HTTPRequest describes a served request, Route is the matched route pattern
+// rather than the path so that ids do not blow up the label cardinality.
+type HTTPRequest struct {
+	Method       string
+	Route        string
+	APIVersion   string
+	Deprecated   bool
+	Status       int
+	Duration     time.Duration
+	RequestSize  int64
+	ResponseSize int64
+}
 
-	res := resource.NewWithAttributes(
-		semconv.SchemaURL,
-		semconv.ServiceName("gateway"),
-	)
+// Instruments are created against the global provider, which forwards them to
+// the one Init installs, so recording is safe before Init and in commands
+// that never call it.
+func init() {
+	mustCreateInstruments(otel.Meter("gateway.metrics"))
+}
 
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
-	)
+// Init starts tracking the SLO burn rates and installs the global meter
+// provider, see telemetry.InitMetrics.
+func Init(ctx context.Context, collectorAddr string, cfg Config) func(ctx context.Context) error {
+	burnRates.Store(newBurnRateTracker(cfg.SLO))
 
-	otel.SetMeterProvider(mp)
+	return telemetry.InitMetrics(ctx, "gateway", collectorAddr, cfg.MetricsConfig)
+}
 
-	meter := otel.Meter("gateway.metrics")
+func mustCreateInstruments(meter metric.Meter) {
+	var err error
 
 	responseTimeHistogram, err = meter.Float64Histogram("http_response_time_ms",
-		metric.WithDescription("Response time by path"),
+		metric.WithDescription("Response time by method, route and status class"),
 		metric.WithUnit("ms"),
 		metric.WithExplicitBucketBoundaries(
 			5, 10, 25, 50, 100,
@@ -58,6 +83,45 @@ func MustInit(ctx context.Context, collectorAddr string) func() {
 		panic(err)
 	}
 
+	activeRequestsCounter, err = meter.Int64UpDownCounter("http_active_requests",
+		metric.WithDescription("Requests being served by method and route"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	sizeBuckets := metric.WithExplicitBucketBoundaries(
+		128, 512, 1024, 4096, 16384,
+		65536, 262144, 1048576,
+	)
+
+	requestSizeHistogram, err = meter.Int64Histogram("http_request_size_bytes",
+		metric.WithDescription("Request body size by method and route"),
+		metric.WithUnit("By"),
+		sizeBuckets,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	responseSizeHistogram, err = meter.Int64Histogram("http_response_size_bytes",
+		metric.WithDescription("Response body size by method and route"),
+		metric.WithUnit("By"),
+		sizeBuckets,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	apiRequestsCounter, err = meter.Int64Counter("http_api_requests",
+		metric.WithDescription("Requests by API version, route and whether the operation is deprecated"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	moviesCounter, err = meter.Int64Histogram("etl_movies_batch_size",
 		metric.WithDescription("Batch size we got from ETL service"),
 		metric.WithUnit("{count}"),
@@ -76,20 +140,127 @@ func MustInit(ctx context.Context, collectorAddr string) func() {
 	}
 
 	dbResultCounter, err = meter.Int64Counter("app_operation_results",
-		metric.WithDescription("Results of operations: success or error types"),
+		metric.WithDescription("Results of database operations by operation and table: success or error types"),
 		metric.WithUnit("{count}"),
 	)
 	if err != nil {
 		panic(err)
 	}
 
+	dbDurationHistogram, err = meter.Float64Histogram("db_operation_duration_ms",
+		metric.WithDescription("Database operation latency by database, operation, table and result"),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(
+			1, 2.5, 5, 10, 25, 50,
+			100, 250, 500, 1000, 2500, 5000,
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	poolConnectionsGauge, err = meter.Int64ObservableGauge("db_pool_connections",
+		metric.WithDescription("Open connections by database and state: in_use or idle"),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	poolMaxOpenGauge, err = meter.Int64ObservableGauge("db_pool_max_open",
+		metric.WithDescription("Connection limit of the pool, zero is unlimited"),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	poolWaitCounter, err = meter.Int64ObservableCounter("db_pool_waits",
+		metric.WithDescription("Times a query waited for a free connection"),
+		metric.WithUnit("{wait}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	poolWaitDurationCounter, err = meter.Float64ObservableCounter("db_pool_wait_duration_ms",
+		metric.WithDescription("Total time spent waiting for a free connection"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	sloBurnRateGauge, err = meter.Float64ObservableGauge("db_slo_burn_rate",
+		metric.WithDescription("Error budget burn rate of database operations by window, 1 spends the budget exactly over the SLO period"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = meter.RegisterCallback(observePools, poolConnectionsGauge, poolMaxOpenGauge, poolWaitCounter, poolWaitDurationCounter)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = meter.RegisterCallback(observeBurnRates, sloBurnRateGauge)
+	if err != nil {
+		panic(err)
+	}
+
+	cacheLookupCounter, err = meter.Int64Counter("cache_lookups",
+		metric.WithDescription("Cache lookups by tier and outcome"),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	queueItemsCounter, err = meter.Int64Counter("queue_items",
+		metric.WithDescription("Items of background write queues by outcome: written, failed or dropped"),
+		metric.WithUnit("{item}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// StartRequest counts the request as in flight until the returned function is
+// called.
+func StartRequest(ctx context.Context, method, route string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.String("route", route),
+	)
+
+	activeRequestsCounter.Add(ctx, 1, attrs)
 	return func() {
-		mp.Shutdown(ctx)
+		activeRequestsCounter.Add(ctx, -1, attrs)
 	}
 }
 
-func RecordResponseTime(ctx context.Context, duration time.Duration, path string) {
-	responseTimeHistogram.Record(ctx, float64(duration.Milliseconds()), metric.WithAttributes(attribute.String("path", path)))
+func RecordRequest(ctx context.Context, request HTTPRequest) {
+	route := attribute.String("route", request.Route)
+	method := attribute.String("method", request.Method)
+
+	responseTimeHistogram.Record(ctx, float64(request.Duration.Microseconds())/1000,
+		metric.WithAttributes(method, route, attribute.String("status_class", getStatusClass(request.Status))),
+	)
+
+	if request.APIVersion != "" {
+		apiRequestsCounter.Add(ctx, 1, metric.WithAttributes(method, route,
+			attribute.String("api_version", request.APIVersion),
+			attribute.Bool("deprecated", request.Deprecated),
+		))
+	}
+
+	if request.RequestSize > 0 {
+		requestSizeHistogram.Record(ctx, request.RequestSize, metric.WithAttributes(method, route))
+	}
+	if request.ResponseSize > 0 {
+		responseSizeHistogram.Record(ctx, request.ResponseSize, metric.WithAttributes(method, route))
+	}
 }
 
 func RecordStatusCodeFromAuth(ctx context.Context, code int, path string) {
@@ -106,25 +277,89 @@ func RecordBatchSize(ctx context.Context, size int) {
 	moviesCounter.Record(ctx, int64(size))
 }
 
-func RecordDBResult(ctx context.Context, err error) {
-	status := "ok"
-	if err != nil {
-		switch {
-		case errors.Is(err, repositories.ErrNotFound):
-			status = "not_found"
-		case errors.Is(err, repositories.ErrUnexpected):
-			status = "unexpected"
-		case errors.Is(err, repositories.ErrInvalidInput):
-			status = "invalid_input"
-		default:
-			status = "other_error"
-		}
+// RecordDBResult takes both GORM and repository errors and returns the result
+// it recorded.
+func RecordDBResult(ctx context.Context, operation, table string, err error) string {
+	result := resultOK
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, repositories.ErrNotFound):
+		result = resultNotFound
+	case errors.Is(err, gorm.ErrCheckConstraintViolated), errors.Is(err, repositories.ErrInvalidInput):
+		result = resultInvalidInput
+	default:
+		result = resultUnexpected
 	}
 
 	dbResultCounter.Add(ctx, 1,
-		metric.WithAttributes(attribute.String("result", status)),
+		metric.WithAttributes(
+			attribute.String("operation", operation),
+			attribute.String("table", table),
+			attribute.String("result", result),
+		),
+	)
+
+	return result
+}
+
+func recordDBDuration(ctx context.Context, db, operation, table, result string, duration time.Duration) {
+	dbDurationHistogram.Record(ctx, float64(duration.Microseconds())/1000,
+		metric.WithAttributes(
+			attribute.String("db", db),
+			attribute.String("operation", operation),
+			attribute.String("table", table),
+			attribute.String("result", result),
+		),
 	)
 }
+
+func observePools(_ context.Context, observer metric.Observer) error {
+	for db, stats := range observedPools() {
+		name := attribute.String("db", db)
+
+		observer.ObserveInt64(poolConnectionsGauge, int64(stats.InUse), metric.WithAttributes(name, attribute.String("state", "in_use")))
+		observer.ObserveInt64(poolConnectionsGauge, int64(stats.Idle), metric.WithAttributes(name, attribute.String("state", "idle")))
+		observer.ObserveInt64(poolMaxOpenGauge, int64(stats.MaxOpenConnections), metric.WithAttributes(name))
+		observer.ObserveInt64(poolWaitCounter, stats.WaitCount, metric.WithAttributes(name))
+		observer.ObserveFloat64(poolWaitDurationCounter, float64(stats.WaitDuration.Microseconds())/1000, metric.WithAttributes(name))
+	}
+
+	return nil
+}
+
+func observeBurnRates(_ context.Context, observer metric.Observer) error {
+	for _, rate := range burnRates.Load().rates() {
+		observer.ObserveFloat64(sloBurnRateGauge, rate.value,
+			metric.WithAttributes(
+				attribute.String("db", rate.key.db),
+				attribute.String("operation", rate.key.operation),
+				attribute.String("table", rate.key.table),
+				attribute.String("window", rate.window),
+			),
+		)
+	}
+
+	return nil
+}
+
+func RecordCacheLookup(ctx context.Context, tier string, hit bool) {
+	cacheLookupCounter.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("tier", tier),
+			attribute.Bool("hit", hit),
+		),
+	)
+}
+
+func RecordQueueItems(ctx context.Context, queue, outcome string, count int) {
+	queueItemsCounter.Add(ctx, int64(count),
+		metric.WithAttributes(
+			attribute.String("queue", queue),
+			attribute.String("outcome", outcome),
+		),
+	)
+}
+
 func getStatusClass(status int) string {
 	switch {
 	case status >= 200 && status < 300:
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var burnRates atomic.Pointer[burnRateTracker]
+
+func init() {
+	burnRates.Store(newBurnRateTracker(SLOConfig{}))
+}
+
+type sloKey struct {
+	db        string
+	operation string
+	table     string
+}
+
+type sloBucket struct {
+	slot      int64
+	good, bad int64
+}
+
+type sloSeries struct {
+	objective Objective
+	buckets   []sloBucket
+}
+
+type burnRate struct {
+	key    sloKey
+	window string
+	value  float64
+}
+
+// burnRateTracker counts good and bad operations in fixed slots, a ring of
+// them covers the longest window. A burn rate of 1 spends the error budget
+// exactly over the SLO period, above 1 spends it faster.
+type burnRateTracker struct {
+	cfg        SLOConfig
+	resolution time.Duration
+	slots      int
+	now        func() time.Time
+
+	mu     sync.Mutex
+	series map[sloKey]*sloSeries
+}
+
+func newBurnRateTracker(cfg SLOConfig) *burnRateTracker {
+	shortest, longest := time.Duration(0), time.Duration(0)
+	for _, window := range cfg.Windows {
+		if shortest == 0 || window < shortest {
+			shortest = window
+		}
+		longest = max(longest, window)
+	}
+
+	resolution := max(shortest/10, time.Second)
+	if longest <= 0 {
+		cfg.Windows = nil
+	}
+
+	return &burnRateTracker{
+		cfg:        cfg,
+		resolution: resolution,
+		slots:      int(longest/resolution) + 1,
+		now:        time.Now,
+		series:     make(map[sloKey]*sloSeries),
+	}
+}
+
+func (t *burnRateTracker) objective(key sloKey) (Objective, bool) {
+	objective, ok := t.cfg.Operations[key.table+"."+key.operation]
+	if !ok {
+		objective = t.cfg.Default
+	}
+
+	return objective, objective.Latency > 0 && objective.Target > 0 && objective.Target < 1
+}
+
+func (t *burnRateTracker) observe(key sloKey, duration time.Duration, failed bool) {
+	if len(t.cfg.Windows) == 0 {
+		return
+	}
+
+	objective, ok := t.objective(key)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	series, ok := t.series[key]
+	if !ok {
+		series = &sloSeries{objective: objective, buckets: make([]sloBucket, t.slots)}
+		t.series[key] = series
+	}
+
+	slot := t.now().UnixNano() / int64(t.resolution)
+	bucket := &series.buckets[slot%int64(len(series.buckets))]
+	if bucket.slot != slot {
+		*bucket = sloBucket{slot: slot}
+	}
+
+	if failed || duration > objective.Latency {
+		bucket.bad++
+	} else {
+		bucket.good++
+	}
+}
+
+// rates skips windows without any operations, there is nothing to burn.
+func (t *burnRateTracker) rates() []burnRate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now().UnixNano() / int64(t.resolution)
+
+	var rates []burnRate
+	for key, series := range t.series {
+		for _, window := range t.cfg.Windows {
+			oldest := now - int64(window/t.resolution)
+
+			var good, bad int64
+			for _, bucket := range series.buckets {
+				if bucket.slot > oldest && bucket.slot <= now {
+					good += bucket.good
+					bad += bucket.bad
+				}
+			}
+
+			if good+bad == 0 {
+				continue
+			}
+
+			rates = append(rates, burnRate{
+				key:    key,
+				window: formatWindow(window),
+				value:  float64(bad) / float64(good+bad) / (1 - series.objective.Target),
+			})
+		}
+	}
+
+	return rates
+}
+
+func formatWindow(window time.Duration) string {
+	switch {
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%dm", window/time.Minute)
+	default:
+		return window.String()
+	}
+}
@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	moviesQuery = sloKey{db: "postgres", operation: "query", table: "movies"}
+	usersQuery  = sloKey{db: "postgres", operation: "query", table: "users"}
+)
+
+// sloStep moves the clock forward and then observes operations of key: good
+// ones, failed ones and ones slower than the objective.
+type sloStep struct {
+	advance         time.Duration
+	key             sloKey
+	good, bad, slow int
+}
+
+func TestBurnRateTracker(t *testing.T) {
+	// The 1m window gives slots of 6s, the ring holds 5m/6s + 1 = 51 of them.
+	cfg := SLOConfig{
+		Windows: []time.Duration{time.Minute, 5 * time.Minute},
+		Default: Objective{Latency: 100 * time.Millisecond, Target: 0.9},
+		Operations: map[string]Objective{
+			"users.query": {Latency: 100 * time.Millisecond, Target: 0.99},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		steps []sloStep
+		// want is keyed by window, windows without operations are left out.
+		want map[string]float64
+	}{
+		{
+			name:  "nothing observed",
+			steps: nil,
+			want:  map[string]float64{},
+		},
+		{
+			name:  "all good",
+			steps: []sloStep{{key: moviesQuery, good: 10}},
+			want:  map[string]float64{"1m": 0, "5m": 0},
+		},
+		{
+			name:  "burning exactly the budget",
+			steps: []sloStep{{key: moviesQuery, good: 9, bad: 1}},
+			want:  map[string]float64{"1m": 1, "5m": 1},
+		},
+		{
+			name:  "slow operations are bad",
+			steps: []sloStep{{key: moviesQuery, good: 8, slow: 2}},
+			want:  map[string]float64{"1m": 2, "5m": 2},
+		},
+		{
+			name:  "operation objective",
+			steps: []sloStep{{key: usersQuery, good: 9, bad: 1}},
+			want:  map[string]float64{"1m": 10, "5m": 10},
+		},
+		{
+			name: "old operations leave the short window",
+			steps: []sloStep{
+				{key: moviesQuery, bad: 10},
+				{advance: 2 * time.Minute, key: moviesQuery, good: 10},
+			},
+			want: map[string]float64{"1m": 0, "5m": 5},
+		},
+		{
+			name: "spread over slots",
+			steps: []sloStep{
+				{key: moviesQuery, bad: 1},
+				{advance: 20 * time.Second, key: moviesQuery, good: 1},
+				{advance: 20 * time.Second, key: moviesQuery, good: 2},
+			},
+			want: map[string]float64{"1m": 2.5, "5m": 2.5},
+		},
+		{
+			name: "everything expired",
+			steps: []sloStep{
+				{key: moviesQuery, bad: 10},
+				{advance: 6 * time.Minute},
+			},
+			want: map[string]float64{},
+		},
+		{
+			// A full turn of the ring lands on the slot of the failures, which
+			// must be reset rather than added to.
+			name: "slot wrap around",
+			steps: []sloStep{
+				{key: moviesQuery, bad: 10},
+				{advance: 51 * 6 * time.Second, key: moviesQuery, good: 1},
+			},
+			want: map[string]float64{"1m": 0, "5m": 0},
+		},
+		{
+			name: "wrap around keeps recent slots",
+			steps: []sloStep{
+				{key: moviesQuery, bad: 1},
+				{advance: 4 * time.Minute, key: moviesQuery, good: 1},
+				{advance: 2 * time.Minute, key: moviesQuery, good: 2},
+			},
+			want: map[string]float64{"1m": 0, "5m": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(600_000, 0)
+			tracker := newBurnRateTracker(cfg)
+			tracker.now = func() time.Time { return now }
+
+			for _, step := range tt.steps {
+				now = now.Add(step.advance)
+
+				for range step.good {
+					tracker.observe(step.key, time.Millisecond, false)
+				}
+				for range step.bad {
+					tracker.observe(step.key, time.Millisecond, true)
+				}
+				for range step.slow {
+					tracker.observe(step.key, time.Second, false)
+				}
+			}
+
+			got := map[string]float64{}
+			for _, rate := range tracker.rates() {
+				got[rate.window] = rate.value
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got rates %v, want %v", got, tt.want)
+			}
+			for window, want := range tt.want {
+				if value, ok := got[window]; !ok || math.Abs(value-want) > 1e-9 {
+					t.Errorf("window %s: got %v, want %v", window, got[window], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBurnRateTrackerUntracked(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SLOConfig
+	}{
+		{"no windows", SLOConfig{Default: Objective{Latency: time.Second, Target: 0.9}}},
+		{"no latency", SLOConfig{Windows: []time.Duration{time.Minute}, Default: Objective{Target: 0.9}}},
+		{"target of 1", SLOConfig{Windows: []time.Duration{time.Minute}, Default: Objective{Latency: time.Second, Target: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newBurnRateTracker(tt.cfg)
+			tracker.observe(moviesQuery, time.Millisecond, true)
+
+			if rates := tracker.rates(); len(rates) != 0 {
+				t.Errorf("got rates %v for an untracked operation", rates)
+			}
+		})
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	tests := []struct {
+		window time.Duration
+		want   string
+	}{
+		{6 * time.Hour, "6h"},
+		{30 * time.Minute, "30m"},
+		{90 * time.Second, "1m30s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatWindow(tt.window); got != tt.want {
+			t.Errorf("formatWindow(%s) = %q, want %q", tt.window, got, tt.want)
+		}
+	}
+}
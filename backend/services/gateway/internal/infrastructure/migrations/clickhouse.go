@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// clickhouseStore records every up and down as a new row, ClickHouse has no
+// transactions and deletes are asynchronous mutations. The latest row of a
+// version wins.
+type clickhouseStore struct {
+	db *gorm.DB
+}
+
+func NewClickhouse(db *gorm.DB) (*Migrator, error) {
+	return newMigrator("clickhouse", &clickhouseStore{db: db}, clickhouseScripts, "clickhouse")
+}
+
+func (cs *clickhouseStore) ensure(ctx context.Context) error {
+	return cs.db.WithContext(ctx).Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version UInt64,
+			name String,
+			applied UInt8,
+			applied_at DateTime64(3)
+		) ENGINE = ReplacingMergeTree(applied_at)
+		ORDER BY version
+	`).Error
+}
+
+func (cs *clickhouseStore) applied(ctx context.Context) (map[int]time.Time, error) {
+	var rows []struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	if err := cs.db.WithContext(ctx).
+		Raw("SELECT version, applied_at FROM schema_migrations FINAL WHERE applied = 1").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row.AppliedAt
+	}
+
+	return result, nil
+}
+
+func (cs *clickhouseStore) apply(ctx context.Context, m Migration) error {
+	for _, statement := range statements(m.Up) {
+		if err := cs.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return cs.record(ctx, m, 1)
+}
+
+func (cs *clickhouseStore) revert(ctx context.Context, m Migration) error {
+	for _, statement := range statements(m.Down) {
+		if err := cs.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return cs.record(ctx, m, 0)
+}
+
+func (cs *clickhouseStore) record(ctx context.Context, m Migration, applied uint8) error {
+	return cs.db.WithContext(ctx).Exec(
+		"INSERT INTO schema_migrations (version, name, applied, applied_at) VALUES (?, ?, ?, now64(3))",
+		m.Version, m.Name, applied,
+	).Error
+}
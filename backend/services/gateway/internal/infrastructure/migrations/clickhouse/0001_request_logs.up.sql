@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS request_logs (
+    trace_id String,
+    timestamp DateTime64(3),
+    method String,
+    path String,
+    normalized_path String,
+    status_code Int64,
+    status_class String,
+    duration_ms Int64,
+    user_id Int64
+) ENGINE = MergeTree
+ORDER BY (timestamp, trace_id);
@@ -0,0 +1 @@
+ALTER TABLE request_logs MODIFY TTL toDateTime(timestamp) + INTERVAL 30 DAY;
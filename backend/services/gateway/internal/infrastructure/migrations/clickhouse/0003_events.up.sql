@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS events (
+    timestamp DateTime64(3),
+    type LowCardinality(String),
+    user_id Int64,
+    movie_id Int64,
+    query String,
+    result_count Int64,
+    rating Float32,
+    trace_id String
+) ENGINE = MergeTree
+ORDER BY (type, timestamp)
+TTL toDateTime(timestamp) + INTERVAL 90 DAY;
@@ -0,0 +1,243 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	//go:embed postgres/*.sql
+	postgresScripts embed.FS
+
+	//go:embed clickhouse/*.sql
+	clickhouseScripts embed.FS
+)
+
+var (
+	ErrSchemaOutdated  = errors.New("database schema is outdated, run `gateway migrate up`")
+	ErrNothingToRevert = errors.New("no applied migrations to revert")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// store keeps track of applied versions and runs scripts in the dialect of
+// one database.
+type store interface {
+	ensure(ctx context.Context) error
+	applied(ctx context.Context) (map[int]time.Time, error)
+	apply(ctx context.Context, m Migration) error
+	revert(ctx context.Context, m Migration) error
+}
+
+type Migrator struct {
+	Database string
+
+	store      store
+	migrations []Migration
+}
+
+func newMigrator(database string, s store, scripts fs.FS, dir string) (*Migrator, error) {
+	migrations, err := load(scripts, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		Database:   database,
+		store:      s,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order and returns how many
+// were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := m.store.apply(ctx, migration); err != nil {
+			return count, fmt.Errorf("%s migration %04d_%s: %w", m.Database, migration.Version, migration.Name, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Down reverts up to steps most recent applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if err := m.store.revert(ctx, migration); err != nil {
+			return count, fmt.Errorf("%s migration %04d_%s: %w", m.Database, migration.Version, migration.Name, err)
+		}
+		count++
+	}
+
+	if count == 0 && steps > 0 {
+		return 0, ErrNothingToRevert
+	}
+
+	return count, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		result = append(result, Status{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// Verify fails when a migration known to this binary has not been applied.
+// Versions applied by a newer binary are fine, schema changes are expected to
+// stay backward compatible for one release.
+func (m *Migrator) Verify(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if !status.Applied {
+			return fmt.Errorf("%w: %s is missing %04d_%s", ErrSchemaOutdated, m.Database, status.Version, status.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	if err := m.store.ensure(ctx); err != nil {
+		return nil, fmt.Errorf("unable to create %s migrations table: %w", m.Database, err)
+	}
+
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s migrations: %w", m.Database, err)
+	}
+
+	return applied, nil
+}
+
+func load(scripts fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(scripts, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s/%s", dir, entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+
+		content, err := fs.ReadFile(scripts, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %s/%04d has conflicting names", dir, version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %s/%04d_%s needs both up and down scripts", dir, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// statements splits a script into single statements, the drivers do not
+// accept several of them in one Exec.
+func statements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var result []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			result = append(result, statement)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockKey is the advisory lock every gateway replica takes before touching
+// the schema, so replicas starting together do not migrate concurrently.
+const lockKey = 7_263_548_190
+
+type postgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgres(db *gorm.DB) (*Migrator, error) {
+	return newMigrator("postgres", &postgresStore{db: db}, postgresScripts, "postgres")
+}
+
+// WithLock runs fn while holding a session level advisory lock in Postgres.
+// It is used for the ClickHouse migrations as well, which has no locks of its
+// own.
+func WithLock(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		return fn(ctx)
+	})
+}
+
+func (ps *postgresStore) ensure(ctx context.Context) error {
+	return ps.db.WithContext(ctx).Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`).Error
+}
+
+func (ps *postgresStore) applied(ctx context.Context) (map[int]time.Time, error) {
+	var rows []struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	if err := ps.db.WithContext(ctx).
+		Raw("SELECT version, applied_at FROM schema_migrations").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row.AppliedAt
+	}
+
+	return result, nil
+}
+
+func (ps *postgresStore) apply(ctx context.Context, m Migration) error {
+	return ps.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements(m.Up) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name).Error
+	})
+}
+
+func (ps *postgresStore) revert(ctx context.Context, m Migration) error {
+	return ps.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements(m.Down) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error
+	})
+}
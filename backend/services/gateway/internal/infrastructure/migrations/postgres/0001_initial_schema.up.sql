@@ -0,0 +1,65 @@
+-- Tables may already exist on databases that were set up by AutoMigrate, so
+-- the baseline only creates what is missing.
+CREATE TABLE IF NOT EXISTS actors (
+    id BIGSERIAL PRIMARY KEY,
+    tmdb_id BIGINT,
+    name TEXT,
+    gender BIGINT,
+    profile_path TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_actor_tmdb_id ON actors (tmdb_id);
+
+CREATE TABLE IF NOT EXISTS genres (
+    id BIGSERIAL PRIMARY KEY,
+    tmdb_id BIGINT,
+    name TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genre_tmdb_id ON genres (tmdb_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genre_name ON genres (name);
+
+CREATE TABLE IF NOT EXISTS movies (
+    id BIGSERIAL PRIMARY KEY,
+    tmdb_id BIGINT,
+    title TEXT,
+    overview TEXT,
+    release_date TIMESTAMPTZ,
+    poster_path TEXT,
+    the_movie_db_vote_average DECIMAL,
+    the_movie_db_vote_count BIGINT,
+    vote_average DECIMAL,
+    vote_count BIGINT,
+    adult BOOLEAN,
+    revenue BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movie_tmdb_id ON movies (tmdb_id);
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+    movie_id BIGINT,
+    genre_id BIGINT,
+    PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie_actors (
+    movie_id BIGINT,
+    actor_id BIGINT,
+    PRIMARY KEY (movie_id, actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    user_id BIGINT,
+    movie_id BIGINT,
+    liked BOOLEAN,
+    title VARCHAR(255),
+    text TEXT,
+    PRIMARY KEY (user_id, movie_id)
+);
+
+CREATE TABLE IF NOT EXISTS ratings (
+    user_id BIGINT,
+    movie_id BIGINT,
+    rating DECIMAL,
+    PRIMARY KEY (user_id, movie_id)
+);
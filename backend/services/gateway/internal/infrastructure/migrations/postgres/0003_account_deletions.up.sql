@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id BIGINT PRIMARY KEY,
+    step TEXT,
+    movie_ids TEXT,
+    attempts BIGINT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_step ON account_deletions (step);
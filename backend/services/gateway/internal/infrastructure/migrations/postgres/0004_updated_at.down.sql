@@ -0,0 +1,3 @@
+ALTER TABLE reviews DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE actors DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE movies DROP COLUMN IF EXISTS updated_at;
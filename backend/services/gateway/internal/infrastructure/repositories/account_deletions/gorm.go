@@ -0,0 +1,58 @@
+package accountdeletions
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormAccountDeletions struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func NewGORMRepository(db *gorm.DB, timeout time.Duration) repositories.AccountDeletions {
+	return &gormAccountDeletions{db: db, timeout: timeout}
+}
+
+// Create is idempotent: requesting deletion twice keeps the original job and
+// its progress.
+func (gad *gormAccountDeletions) Create(ctx context.Context, deletion entities.AccountDeletion) (entities.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, gad.timeout)
+	defer cancel()
+
+	if err := gad.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deletion).Error; err != nil {
+		return entities.AccountDeletion{}, errorwrap.Wrap(ctx, err)
+	}
+
+	var stored entities.AccountDeletion
+	err := gad.db.WithContext(ctx).First(&stored, "user_id = ?", deletion.UserID).Error
+	return stored, errorwrap.Wrap(ctx, err)
+}
+
+func (gad *gormAccountDeletions) GetUnfinished(ctx context.Context, limit int) ([]entities.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, gad.timeout)
+	defer cancel()
+
+	var deletions []entities.AccountDeletion
+	err := gad.db.WithContext(ctx).
+		Where("step NOT IN ?", []entities.AccountDeletionStep{entities.AccountDeletionDone, entities.AccountDeletionFailed}).
+		Order("updated_at").
+		Limit(limit).
+		Find(&deletions).Error
+	return deletions, errorwrap.Wrap(ctx, err)
+}
+
+func (gad *gormAccountDeletions) Update(ctx context.Context, deletion entities.AccountDeletion) error {
+	ctx, cancel := context.WithTimeout(ctx, gad.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, gad.db.WithContext(ctx).Save(&deletion).Error)
+}
@@ -0,0 +1,28 @@
+package actors
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/cache"
+)
+
+type cachedActors struct {
+	repositories.Actors
+
+	cache *cache.Cache
+}
+
+func NewCachedRepository(next repositories.Actors, c *cache.Cache) repositories.Actors {
+	return &cachedActors{
+		Actors: next,
+		cache:  c,
+	}
+}
+
+func (ca *cachedActors) GetByID(ctx context.Context, id int) (entities.Actor, error) {
+	return cache.Fetch(ctx, ca.cache, cache.ActorKey(id), ca.cache.TTL(), func(ctx context.Context) (entities.Actor, error) {
+		return ca.Actors.GetByID(ctx, id)
+	})
+}
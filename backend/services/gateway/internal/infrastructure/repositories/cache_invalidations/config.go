@@ -0,0 +1,5 @@
+package cacheinvalidations
+
+type Config struct {
+	Channel string
+}
@@ -0,0 +1,76 @@
+package cacheinvalidations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisInvalidations struct {
+	client *redis.Client
+
+	cfg     Config
+	timeout time.Duration
+}
+
+func NewRedisRepository(client *redis.Client, cfg Config, timeout time.Duration) repositories.CacheInvalidations {
+	return &redisInvalidations{
+		client:  client,
+		cfg:     cfg,
+		timeout: timeout,
+	}
+}
+
+func (ri *redisInvalidations) Publish(ctx context.Context, invalidation entities.CacheInvalidation) error {
+	ctx, cancel := context.WithTimeout(ctx, ri.timeout)
+	defer cancel()
+
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	if err := ri.client.Publish(ctx, ri.cfg.Channel, payload).Err(); err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return nil
+}
+
+func (ri *redisInvalidations) Subscribe(ctx context.Context) <-chan entities.CacheInvalidation {
+	ch := make(chan entities.CacheInvalidation)
+
+	go func() {
+		pubsub := ri.client.Subscribe(ctx, ri.cfg.Channel)
+		defer pubsub.Close()
+
+		sub := pubsub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-sub:
+				var invalidation entities.CacheInvalidation
+				if err := json.Unmarshal([]byte(msg.Payload), &invalidation); err != nil {
+					ctxlogrus.Extract(ctx).Warnf("unable to unmarshal cache invalidation: %s", err.Error())
+					continue
+				}
+
+				select {
+				case ch <- invalidation:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch
+}
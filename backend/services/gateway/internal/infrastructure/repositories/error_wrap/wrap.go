@@ -6,25 +6,20 @@ import (
 	"fmt"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/metrics"
 	"gorm.io/gorm"
 )
 
-func Wrap(ctx context.Context, err error) error {
-	var result error
-	defer func() {
-		metrics.RecordDBResult(ctx, result)
-	}()
-
-	if err == nil {
-		result = nil
-	} else if errors.Is(err, gorm.ErrRecordNotFound) {
-		result = repositories.ErrNotFound
-	} else if errors.Is(err, gorm.ErrCheckConstraintViolated) {
-		result = repositories.ErrInvalidInput
-	} else {
-		result = fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+// Wrap maps GORM errors to repository errors. Results are counted by the
+// metrics GORM plugin, which knows the operation and table.
+func Wrap(_ context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repositories.ErrNotFound
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return repositories.ErrInvalidInput
+	default:
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
 	}
-
-	return result
 }
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/batch"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+)
+
+// BatchWriter queues events and writes them in batches, queries go straight
+// to the database.
+type BatchWriter struct {
+	repositories.Events
+
+	*batch.Queue[entities.Event]
+}
+
+func NewBatchWriter(db *gorm.DB, cfg batch.Config, timeout time.Duration) *BatchWriter {
+	return &BatchWriter{
+		Events: NewGORMRepository(db, timeout),
+		Queue: batch.New("events", cfg, func(ctx context.Context, events []entities.Event) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return db.WithContext(ctx).Create(&events).Error
+		}),
+	}
+}
+
+func (bw *BatchWriter) Record(ctx context.Context, event entities.Event) error {
+	if err := bw.Push(ctx, event); err != nil && !errors.Is(err, batch.ErrDropped) {
+		return errorwrap.Wrap(ctx, err)
+	}
+
+	return nil
+}
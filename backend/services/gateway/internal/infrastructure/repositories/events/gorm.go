@@ -0,0 +1,31 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+)
+
+type gormEvents struct {
+	db *gorm.DB
+
+	timeout time.Duration
+}
+
+func NewGORMRepository(db *gorm.DB, timeout time.Duration) repositories.Events {
+	return &gormEvents{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (ge *gormEvents) Record(ctx context.Context, event entities.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, ge.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, ge.db.WithContext(ctx).Create(&event).Error)
+}
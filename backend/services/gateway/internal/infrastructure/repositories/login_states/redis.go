@@ -0,0 +1,68 @@
+package loginstates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisLoginStates struct {
+	client *redis.Client
+
+	timeout time.Duration
+}
+
+func NewRedisRepository(client *redis.Client, timeout time.Duration) repositories.LoginStates {
+	return &redisLoginStates{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (rls *redisLoginStates) Save(ctx context.Context, state string, loginState entities.LoginState, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, rls.timeout)
+	defer cancel()
+
+	raw, err := json.Marshal(loginState)
+	if err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	if err := rls.client.Set(ctx, stateKey(state), raw, ttl).Err(); err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return nil
+}
+
+// Pop reads and deletes the state in one step, so every state can be
+// redeemed exactly once.
+func (rls *redisLoginStates) Pop(ctx context.Context, state string) (entities.LoginState, error) {
+	ctx, cancel := context.WithTimeout(ctx, rls.timeout)
+	defer cancel()
+
+	raw, err := rls.client.GetDel(ctx, stateKey(state)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return entities.LoginState{}, repositories.ErrNotFound
+	}
+	if err != nil {
+		return entities.LoginState{}, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	var loginState entities.LoginState
+	if err := json.Unmarshal(raw, &loginState); err != nil {
+		return entities.LoginState{}, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return loginState, nil
+}
+
+func stateKey(state string) string {
+	return "oidc_state:" + state
+}
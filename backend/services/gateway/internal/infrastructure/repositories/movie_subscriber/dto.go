@@ -1,5 +1,10 @@
 package moviesubscriber
 
+type message struct {
+	Trace  map[string]string `json:"trace"`
+	Movies []movie           `json:"movies"`
+}
+
 type movie struct {
 	ID          int64   `json:"id"`
 	Title       string  `json:"title"`
@@ -3,6 +3,7 @@ package moviesubscriber
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
@@ -24,8 +25,8 @@ func NewRedisSubcriber(client *redis.Client, cfg Config) repositories.MovieSubsc
 	}
 }
 
-func (rs *redisSubscriber) Subscribe(ctx context.Context) <-chan []entities.Movie {
-	ch := make(chan []entities.Movie)
+func (rs *redisSubscriber) Subscribe(ctx context.Context) <-chan entities.MovieBatch {
+	ch := make(chan entities.MovieBatch)
 
 	go func() {
 		sub := rs.client.Subscribe(ctx, rs.cfg.SubscribeChannel).Channel()
@@ -35,19 +36,23 @@ func (rs *redisSubscriber) Subscribe(ctx context.Context) <-chan []entities.Movi
 			case <-ctx.Done():
 				return
 			case msg := <-sub:
-				var movies []movie
-				if err := json.Unmarshal([]byte(msg.Payload), &movies); err != nil {
+				message, err := decodeMessage(msg.Payload)
+				if err != nil {
 					ctxlogrus.Extract(ctx).Warn("unable to unmrshal data from redis")
 					continue
 				}
 
-				domainMovies, err := toDomainMovies(movies)
+				domainMovies, err := toDomainMovies(message.Movies)
 				if err != nil {
 					ctxlogrus.Extract(ctx).Warnf("unable to transfer dto movies to domain movies: %s", err.Error())
 					continue
 				}
 
-				ch <- domainMovies
+				select {
+				case ch <- entities.MovieBatch{Movies: domainMovies, Trace: message.Trace}:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
@@ -55,6 +60,20 @@ func (rs *redisSubscriber) Subscribe(ctx context.Context) <-chan []entities.Movi
 	return ch
 }
 
+// decodeMessage also accepts the bare array of movies older etl versions
+// publish, they carry no trace context.
+func decodeMessage(payload string) (message, error) {
+	if strings.HasPrefix(strings.TrimSpace(payload), "[") {
+		var movies []movie
+		err := json.Unmarshal([]byte(payload), &movies)
+		return message{Movies: movies}, err
+	}
+
+	var msg message
+	err := json.Unmarshal([]byte(payload), &msg)
+	return msg, err
+}
+
 func toDomainMovies(movies []movie) ([]entities.Movie, error) {
 	result := make([]entities.Movie, 0, len(movies))
 
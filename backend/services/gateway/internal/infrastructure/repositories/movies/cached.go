@@ -0,0 +1,95 @@
+package movies
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/cache"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+// cachedMovies serves GetByID and GetPopular through the read-through cache.
+// Vote changes are published as invalidations so every replica drops the
+// movie, batch inserts are invalidated by the poller that runs them.
+type cachedMovies struct {
+	repositories.Movies
+
+	cache         *cache.Cache
+	invalidations repositories.CacheInvalidations
+}
+
+func NewCachedRepository(next repositories.Movies, c *cache.Cache, invalidations repositories.CacheInvalidations) repositories.Movies {
+	return &cachedMovies{
+		Movies:        next,
+		cache:         c,
+		invalidations: invalidations,
+	}
+}
+
+func (cm *cachedMovies) GetByID(ctx context.Context, id int) (entities.Movie, error) {
+	return cache.Fetch(ctx, cm.cache, cache.MovieKey(id), cm.cache.TTL(), func(ctx context.Context) (entities.Movie, error) {
+		return cm.Movies.GetByID(ctx, id)
+	})
+}
+
+func (cm *cachedMovies) GetPopular(ctx context.Context, offset, limit int) ([]entities.Movie, error) {
+	return cache.Fetch(ctx, cm.cache, cache.PopularMoviesKey(offset, limit), cm.cache.ListTTL(), func(ctx context.Context) ([]entities.Movie, error) {
+		return cm.Movies.GetPopular(ctx, offset, limit)
+	})
+}
+
+func (cm *cachedMovies) AddVote(ctx context.Context, movieID int, newValue float32) error {
+	if err := cm.Movies.AddVote(ctx, movieID, newValue); err != nil {
+		return err
+	}
+
+	cm.invalidate(ctx, movieID)
+	return nil
+}
+
+func (cm *cachedMovies) DeleteVote(ctx context.Context, movieID int, oldValue float32) error {
+	if err := cm.Movies.DeleteVote(ctx, movieID, oldValue); err != nil {
+		return err
+	}
+
+	cm.invalidate(ctx, movieID)
+	return nil
+}
+
+func (cm *cachedMovies) RecomputeVotes(ctx context.Context, movieIDs []int) error {
+	if err := cm.Movies.RecomputeVotes(ctx, movieIDs); err != nil {
+		return err
+	}
+
+	cm.invalidate(ctx, movieIDs...)
+	return nil
+}
+
+func (cm *cachedMovies) RecomputeAllVotes(ctx context.Context) error {
+	if err := cm.Movies.RecomputeAllVotes(ctx); err != nil {
+		return err
+	}
+
+	cm.cache.InvalidateAll(ctx)
+
+	if err := cm.invalidations.Publish(ctx, entities.CacheInvalidation{All: true}); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to publish cache invalidation: %s", err.Error())
+	}
+
+	return nil
+}
+
+// invalidate drops the movies locally right away so the caller reads its own
+// write, then lets the other replicas know.
+func (cm *cachedMovies) invalidate(ctx context.Context, movieIDs ...int) {
+	keys := make([]string, 0, len(movieIDs))
+	for _, id := range movieIDs {
+		keys = append(keys, cache.MovieKey(id))
+	}
+	cm.cache.Invalidate(ctx, keys...)
+
+	if err := cm.invalidations.Publish(ctx, entities.CacheInvalidation{MovieIDs: movieIDs}); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to publish cache invalidation: %s", err.Error())
+	}
+}
@@ -55,68 +55,148 @@ func (gm *gormMovies) GetPopular(ctx context.Context, offset, limit int) ([]enti
 	return movies, err
 }
 
+// GetByIDs loads the movies without genres and actors, ids that do not exist
+// are left out.
+func (gm *gormMovies) GetByIDs(ctx context.Context, ids []int) ([]entities.Movie, error) {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	var movies []entities.Movie
+	err := gm.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Find(&movies).Error
+	return movies, errorwrap.Wrap(ctx, err)
+}
+
+func (gm *gormMovies) GetGenresByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	var rows []struct {
+		MovieID int
+		entities.Genre
+	}
+	err := gm.db.WithContext(ctx).
+		Table("genres").
+		Select("movie_genres.movie_id, genres.*").
+		Joins("JOIN movie_genres ON movie_genres.genre_id = genres.id").
+		Where("movie_genres.movie_id IN ?", movieIDs).
+		Order("genres.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	genres := make(map[int][]entities.Genre, len(movieIDs))
+	for _, row := range rows {
+		genres[row.MovieID] = append(genres[row.MovieID], row.Genre)
+	}
+	return genres, nil
+}
+
+func (gm *gormMovies) GetActorsByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Actor, error) {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	var rows []struct {
+		MovieID int
+		entities.Actor
+	}
+	err := gm.db.WithContext(ctx).
+		Table("actors").
+		Select("movie_actors.movie_id, actors.*").
+		Joins("JOIN movie_actors ON movie_actors.actor_id = actors.id").
+		Where("movie_actors.movie_id IN ?", movieIDs).
+		Order("actors.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	actors := make(map[int][]entities.Actor, len(movieIDs))
+	for _, row := range rows {
+		actors[row.MovieID] = append(actors[row.MovieID], row.Actor)
+	}
+	return actors, nil
+}
+
+func (gm *gormMovies) GetIDsByActorIDs(ctx context.Context, actorIDs []int) (map[int][]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	var rows []movieActor
+	err := gm.db.WithContext(ctx).
+		Where("actor_id IN ?", actorIDs).
+		Order("movie_id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	movieIDs := make(map[int][]int, len(actorIDs))
+	for _, row := range rows {
+		movieIDs[row.ActorID] = append(movieIDs[row.ActorID], row.MovieID)
+	}
+	return movieIDs, nil
+}
+
+// List pages through the whole catalog ordered by id, afterID is the last id
+// of the previous page.
+func (gm *gormMovies) List(ctx context.Context, afterID, limit int) ([]entities.Movie, error) {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	var movies []entities.Movie
+	err := gm.db.WithContext(ctx).
+		Preload("Genres").
+		Preload("Actors").
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&movies).Error
+	return movies, errorwrap.Wrap(ctx, err)
+}
+
+// InsertMovies upserts the batch with a handful of set-based statements:
+// genres, actors and movies are written with INSERT ... ON CONFLICT and the
+// join tables are diffed in bulk. The ids assigned by the database are written
+// back into movies.
 func (gm *gormMovies) InsertMovies(ctx context.Context, movies []entities.Movie) error {
-	return gm.db.Transaction(func(tx *gorm.DB) error {
-		ctx, cancel := context.WithTimeout(ctx, gm.timeout)
-		defer cancel()
-
-		for _, movie := range movies {
-			genres := make([]entities.Genre, len(movie.Genres))
-			for i, g := range movie.Genres {
-				var genre entities.Genre
-				if err := tx.WithContext(ctx).Where("tmdb_id = ?", g.TheMovieDBID).FirstOrCreate(&genre, entities.Genre{
-					TheMovieDBID: g.TheMovieDBID,
-					Name:         g.Name,
-				}).Error; err != nil {
-					return errorwrap.Wrap(ctx, err)
-				}
-				genres[i] = genre
-			}
+	if len(movies) == 0 {
+		return nil
+	}
 
-			actors := make([]entities.Actor, len(movie.Actors))
-			for i, a := range movie.Actors {
-				var actor entities.Actor
-				if err := tx.WithContext(ctx).Where("tmdb_id = ?", a.TheMovieDBID).FirstOrCreate(&actor, entities.Actor{
-					TheMovieDBID: a.TheMovieDBID,
-					Name:         a.Name,
-					Gender:       a.Gender,
-					ProfilePath:  a.ProfilePath,
-				}).Error; err != nil {
-					return errorwrap.Wrap(ctx, err)
-				}
-				actors[i] = actor
-			}
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
 
-			movie.Actors = actors
-			movie.Genres = genres
-
-			var existingMovie entities.Movie
-			err := tx.Where("tmdb_id = ?", movie.TheMovieDBID).First(&existingMovie).Error
-
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				if err := tx.WithContext(ctx).Create(&movie).Error; err != nil {
-					return errorwrap.Wrap(ctx, err)
-				}
-			} else if err == nil {
-				movie.ID = existingMovie.ID
-				if err := tx.WithContext(ctx).Save(&movie).Error; err != nil {
-					return errorwrap.Wrap(ctx, err)
-				}
-			} else {
-				return errorwrap.Wrap(ctx, err)
-			}
+	return errorwrap.Wrap(ctx, gm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		genreIDs, err := upsertGenres(tx, movies)
+		if err != nil {
+			return err
+		}
 
-			if err := tx.WithContext(ctx).Model(&movie).Association("Genres").Replace(genres); err != nil {
-				return errorwrap.Wrap(ctx, err)
+		actorIDs, err := upsertActors(tx, movies)
+		if err != nil {
+			return err
+		}
+
+		movieIDs, err := upsertMovies(tx, movies)
+		if err != nil {
+			return err
+		}
+
+		for i := range movies {
+			movies[i].ID = movieIDs[movies[i].TheMovieDBID]
+			for j := range movies[i].Genres {
+				movies[i].Genres[j].ID = genreIDs[movies[i].Genres[j].TheMovieDBID]
 			}
-			if err := tx.WithContext(ctx).Model(&movie).Association("Actors").Replace(actors); err != nil {
-				return errorwrap.Wrap(ctx, err)
+			for j := range movies[i].Actors {
+				movies[i].Actors[j].ID = actorIDs[movies[i].Actors[j].TheMovieDBID]
 			}
-
 		}
 
-		return errorwrap.Wrap(ctx, nil)
-	})
+		return replaceAssociations(tx, movies)
+	}))
 }
 
 func (gm *gormMovies) AddVote(ctx context.Context, movieID int, newValue float32) error {
@@ -170,3 +250,42 @@ func (gm *gormMovies) DeleteVote(ctx context.Context, movieID int, oldValue floa
 		}).Error)
 	})
 }
+
+// RecomputeVotes rebuilds vote_average and vote_count of the given movies from
+// the ratings table instead of adjusting them incrementally.
+func (gm *gormMovies) RecomputeVotes(ctx context.Context, movieIDs []int) error {
+	if len(movieIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, gm.db.WithContext(ctx).Exec(`
+		UPDATE movies SET
+			vote_average = COALESCE((SELECT AVG(rating) FROM ratings WHERE ratings.movie_id = movies.id), 0),
+			vote_count = (SELECT COUNT(*) FROM ratings WHERE ratings.movie_id = movies.id),
+			updated_at = NOW()
+		WHERE id IN ?
+	`, movieIDs).Error)
+}
+
+func (gm *gormMovies) RecomputeAllVotes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, gm.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, gm.db.WithContext(ctx).Exec(`
+		UPDATE movies SET
+			vote_average = votes.average,
+			vote_count = votes.count,
+			updated_at = NOW()
+		FROM (
+			SELECT m.id, COALESCE(AVG(r.rating), 0) AS average, COUNT(r.movie_id) AS count
+			FROM movies m
+			LEFT JOIN ratings r ON r.movie_id = m.id
+			GROUP BY m.id
+		) AS votes
+		WHERE movies.id = votes.id
+			AND (movies.vote_average, movies.vote_count) IS DISTINCT FROM (votes.average, votes.count)
+	`).Error)
+}
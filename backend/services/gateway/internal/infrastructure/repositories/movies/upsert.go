@@ -0,0 +1,258 @@
+package movies
+
+import (
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// upsertBatchSize keeps every statement well below the Postgres limit of
+// 65535 bind parameters.
+const upsertBatchSize = 1000
+
+// associationChunkSize bounds how many movies share one join-table diff.
+const associationChunkSize = 100
+
+type movieGenre struct {
+	MovieID int `gorm:"primaryKey"`
+	GenreID int `gorm:"primaryKey"`
+}
+
+func (movieGenre) TableName() string {
+	return "movie_genres"
+}
+
+type movieActor struct {
+	MovieID int `gorm:"primaryKey"`
+	ActorID int `gorm:"primaryKey"`
+}
+
+func (movieActor) TableName() string {
+	return "movie_actors"
+}
+
+type tmdbRow struct {
+	ID           int
+	TheMovieDBID int64 `gorm:"column:tmdb_id"`
+}
+
+// Rows are only rewritten when something actually changed, so updated_at
+// and the ETags derived from it stay put when the ETL sends the same data.
+func upsertGenres(tx *gorm.DB, movies []entities.Movie) (map[int]int, error) {
+	seen := make(map[int]int)
+	var genres []entities.Genre
+	for _, movie := range movies {
+		for _, genre := range movie.Genres {
+			if i, ok := seen[genre.TheMovieDBID]; ok {
+				genres[i].Name = genre.Name
+				continue
+			}
+			seen[genre.TheMovieDBID] = len(genres)
+			genres = append(genres, entities.Genre{TheMovieDBID: genre.TheMovieDBID, Name: genre.Name})
+		}
+	}
+
+	if len(genres) == 0 {
+		return map[int]int{}, nil
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "genres.name IS DISTINCT FROM excluded.name"}}},
+	}).CreateInBatches(&genres, upsertBatchSize).Error; err != nil {
+		return nil, err
+	}
+
+	tmdbIDs := make([]int64, 0, len(genres))
+	for _, genre := range genres {
+		tmdbIDs = append(tmdbIDs, int64(genre.TheMovieDBID))
+	}
+
+	ids, err := idsByTMDBID(tx, "genres", tmdbIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]int, len(ids))
+	for tmdbID, id := range ids {
+		result[int(tmdbID)] = id
+	}
+
+	return result, nil
+}
+
+func upsertActors(tx *gorm.DB, movies []entities.Movie) (map[int]int, error) {
+	seen := make(map[int]int)
+	var actors []entities.Actor
+	for _, movie := range movies {
+		for _, actor := range movie.Actors {
+			row := entities.Actor{
+				TheMovieDBID: actor.TheMovieDBID,
+				Name:         actor.Name,
+				Gender:       actor.Gender,
+				ProfilePath:  actor.ProfilePath,
+			}
+
+			if i, ok := seen[actor.TheMovieDBID]; ok {
+				actors[i] = row
+				continue
+			}
+			seen[actor.TheMovieDBID] = len(actors)
+			actors = append(actors, row)
+		}
+	}
+
+	if len(actors) == 0 {
+		return map[int]int{}, nil
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "gender", "profile_path", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{
+			SQL: "(actors.name, actors.gender, actors.profile_path) IS DISTINCT FROM (excluded.name, excluded.gender, excluded.profile_path)",
+		}}},
+	}).CreateInBatches(&actors, upsertBatchSize).Error; err != nil {
+		return nil, err
+	}
+
+	tmdbIDs := make([]int64, 0, len(actors))
+	for _, actor := range actors {
+		tmdbIDs = append(tmdbIDs, int64(actor.TheMovieDBID))
+	}
+
+	ids, err := idsByTMDBID(tx, "actors", tmdbIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]int, len(ids))
+	for tmdbID, id := range ids {
+		result[int(tmdbID)] = id
+	}
+
+	return result, nil
+}
+
+// upsertMovies leaves vote_average and vote_count alone, they are maintained
+// from local ratings and are not part of what the ETL sends.
+func upsertMovies(tx *gorm.DB, movies []entities.Movie) (map[int64]int, error) {
+	seen := make(map[int64]int)
+	var rows []entities.Movie
+	for _, movie := range movies {
+		row := movie
+		row.ID = 0
+		row.Genres = nil
+		row.Actors = nil
+
+		if i, ok := seen[movie.TheMovieDBID]; ok {
+			rows[i] = row
+			continue
+		}
+		seen[movie.TheMovieDBID] = len(rows)
+		rows = append(rows, row)
+	}
+
+	if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"title", "overview", "release_date", "poster_path",
+			"the_movie_db_vote_average", "the_movie_db_vote_count",
+			"adult", "revenue", "updated_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{
+			SQL: `(movies.title, movies.overview, movies.release_date, movies.poster_path,
+				movies.the_movie_db_vote_average, movies.the_movie_db_vote_count, movies.adult, movies.revenue)
+				IS DISTINCT FROM
+				(excluded.title, excluded.overview, excluded.release_date, excluded.poster_path,
+				excluded.the_movie_db_vote_average, excluded.the_movie_db_vote_count, excluded.adult, excluded.revenue)`,
+		}}},
+	}).CreateInBatches(&rows, upsertBatchSize).Error; err != nil {
+		return nil, err
+	}
+
+	tmdbIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		tmdbIDs = append(tmdbIDs, row.TheMovieDBID)
+	}
+
+	return idsByTMDBID(tx, "movies", tmdbIDs)
+}
+
+// replaceAssociations makes the join tables of the batch movies match the
+// batch: stale pairs are deleted and missing ones inserted, pairs that are
+// already there are not touched. Movies are handled in chunks so the NOT IN
+// lists stay bounded.
+func replaceAssociations(tx *gorm.DB, movies []entities.Movie) error {
+	for start := 0; start < len(movies); start += associationChunkSize {
+		chunk := movies[start:min(start+associationChunkSize, len(movies))]
+
+		movieIDs := make([]int, 0, len(chunk))
+		var genres []movieGenre
+		var actors []movieActor
+		var genrePairs, actorPairs [][]any
+		for _, movie := range chunk {
+			movieIDs = append(movieIDs, movie.ID)
+			for _, genre := range movie.Genres {
+				genres = append(genres, movieGenre{MovieID: movie.ID, GenreID: genre.ID})
+				genrePairs = append(genrePairs, []any{movie.ID, genre.ID})
+			}
+			for _, actor := range movie.Actors {
+				actors = append(actors, movieActor{MovieID: movie.ID, ActorID: actor.ID})
+				actorPairs = append(actorPairs, []any{movie.ID, actor.ID})
+			}
+		}
+
+		if err := deleteStalePairs(tx, &movieGenre{}, "genre_id", movieIDs, genrePairs); err != nil {
+			return err
+		}
+		if err := deleteStalePairs(tx, &movieActor{}, "actor_id", movieIDs, actorPairs); err != nil {
+			return err
+		}
+
+		if len(genres) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&genres, upsertBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(actors) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&actors, upsertBatchSize).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func deleteStalePairs(tx *gorm.DB, model any, column string, movieIDs []int, keep [][]any) error {
+	query := tx.Where("movie_id IN ?", movieIDs)
+	if len(keep) > 0 {
+		query = query.Where("(movie_id, "+column+") NOT IN ?", keep)
+	}
+
+	return query.Delete(model).Error
+}
+
+func idsByTMDBID(tx *gorm.DB, table string, tmdbIDs []int64) (map[int64]int, error) {
+	result := make(map[int64]int, len(tmdbIDs))
+
+	for start := 0; start < len(tmdbIDs); start += upsertBatchSize {
+		end := min(start+upsertBatchSize, len(tmdbIDs))
+
+		var rows []tmdbRow
+		if err := tx.Table(table).
+			Select("id", "tmdb_id").
+			Where("tmdb_id IN ?", tmdbIDs[start:end]).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			result[row.TheMovieDBID] = row.ID
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package movies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// The benchmark needs a disposable Postgres database, its tables are
+// truncated between runs:
+//
+//	TEST_DATABASE_DSN="host=localhost user=cinema password=cinema dbname=cinema_bench sslmode=disable" \
+//		go test -run '^$' -bench InsertMovies ./internal/infrastructure/repositories/movies/
+const dsnEnv = "TEST_DATABASE_DSN"
+
+const (
+	benchMovies         = 100
+	benchGenres         = 19
+	benchActors         = 400
+	benchGenresPerMovie = 3
+	benchActorsPerMovie = 20
+)
+
+// benchBatch builds a batch the way the ETL sends it: movies share genres and
+// a good part of their cast, and nothing carries a database id yet.
+func benchBatch(revision int) []entities.Movie {
+	movies := make([]entities.Movie, 0, benchMovies)
+	for i := range benchMovies {
+		movie := entities.Movie{
+			TheMovieDBID:          int64(1_000_000 + i),
+			Title:                 fmt.Sprintf("Movie %d", i),
+			Overview:              fmt.Sprintf("Overview of movie %d, revision %d", i, revision),
+			ReleaseDate:           time.Date(2000+i%25, time.Month(1+i%12), 1, 0, 0, 0, 0, time.UTC),
+			PosterPath:            fmt.Sprintf("/poster-%d.jpg", i),
+			TheMovieDBVoteAverage: float32(i%10) + 0.5,
+			TheMovieDBVoteCount:   1000 + i + revision,
+			Revenue:               1_000_000 * i,
+		}
+
+		for j := range benchGenresPerMovie {
+			id := (i + j) % benchGenres
+			movie.Genres = append(movie.Genres, entities.Genre{
+				TheMovieDBID: 100 + id,
+				Name:         fmt.Sprintf("Genre %d", id),
+			})
+		}
+
+		for j := range benchActorsPerMovie {
+			id := (i*7 + j) % benchActors
+			movie.Actors = append(movie.Actors, entities.Actor{
+				TheMovieDBID: 10_000 + id,
+				Name:         fmt.Sprintf("Actor %d", id),
+				Gender:       id % 3,
+				ProfilePath:  fmt.Sprintf("/actor-%d.jpg", id),
+			})
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies
+}
+
+// insertMoviesPerRow is InsertMovies as it was before the set-based upsert:
+// FirstOrCreate per genre and actor, First plus Save per movie and two
+// association replaces.
+func insertMoviesPerRow(ctx context.Context, db *gorm.DB, movies []entities.Movie) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, movie := range movies {
+			genres := make([]entities.Genre, len(movie.Genres))
+			for i, g := range movie.Genres {
+				var genre entities.Genre
+				if err := tx.Where("tmdb_id = ?", g.TheMovieDBID).FirstOrCreate(&genre, entities.Genre{
+					TheMovieDBID: g.TheMovieDBID,
+					Name:         g.Name,
+				}).Error; err != nil {
+					return err
+				}
+				genres[i] = genre
+			}
+
+			actors := make([]entities.Actor, len(movie.Actors))
+			for i, a := range movie.Actors {
+				var actor entities.Actor
+				if err := tx.Where("tmdb_id = ?", a.TheMovieDBID).FirstOrCreate(&actor, entities.Actor{
+					TheMovieDBID: a.TheMovieDBID,
+					Name:         a.Name,
+					Gender:       a.Gender,
+					ProfilePath:  a.ProfilePath,
+				}).Error; err != nil {
+					return err
+				}
+				actors[i] = actor
+			}
+
+			movie.Actors = actors
+			movie.Genres = genres
+
+			var existing entities.Movie
+			err := tx.Where("tmdb_id = ?", movie.TheMovieDBID).First(&existing).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				err = tx.Create(&movie).Error
+			case err == nil:
+				movie.ID = existing.ID
+				err = tx.Save(&movie).Error
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Model(&movie).Association("Genres").Replace(genres); err != nil {
+				return err
+			}
+			if err := tx.Model(&movie).Association("Actors").Replace(actors); err != nil {
+				return err
+			}
+
+			movies[i] = movie
+		}
+
+		return nil
+	})
+}
+
+func openBenchDB(b *testing.B) *gorm.DB {
+	b.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		b.Skipf("%s is not set", dsnEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	migrator, err := migrations.NewPostgres(db)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+
+	return db
+}
+
+func truncate(b *testing.B, db *gorm.DB) {
+	b.Helper()
+
+	if err := db.Exec("TRUNCATE movie_genres, movie_actors, movies, genres, actors RESTART IDENTITY CASCADE").Error; err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkInsertMovies compares the per-row path with the set-based upsert
+// on a batch of 100 movies, once into empty tables and once as a re-sync of
+// movies that are already stored with slightly different data.
+func BenchmarkInsertMovies(b *testing.B) {
+	db := openBenchDB(b)
+	ctx := context.Background()
+	repo := NewGORMRepository(db, time.Minute)
+
+	paths := []struct {
+		name   string
+		insert func([]entities.Movie) error
+	}{
+		{"per-row", func(movies []entities.Movie) error { return insertMoviesPerRow(ctx, db, movies) }},
+		{"upsert", func(movies []entities.Movie) error { return repo.InsertMovies(ctx, movies) }},
+	}
+
+	for _, path := range paths {
+		b.Run(path.name+"/insert", func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				truncate(b, db)
+				batch := benchBatch(0)
+				b.StartTimer()
+
+				if err := path.insert(batch); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.N*benchMovies)/b.Elapsed().Seconds(), "movies/s")
+		})
+
+		b.Run(path.name+"/resync", func(b *testing.B) {
+			truncate(b, db)
+			if err := path.insert(benchBatch(0)); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for revision := range b.N {
+				if err := path.insert(benchBatch(revision + 1)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.N*benchMovies)/b.Elapsed().Seconds(), "movies/s")
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package ratelimits
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisRateLimits struct {
+	client  *redis.Client
+	limiter *redis_rate.Limiter
+
+	timeout time.Duration
+}
+
+func NewRedisRepository(client *redis.Client, timeout time.Duration) repositories.RateLimits {
+	return &redisRateLimits{
+		client:  client,
+		limiter: redis_rate.NewLimiter(client),
+		timeout: timeout,
+	}
+}
+
+// Allow applies GCRA through a Lua script, so concurrent gateway replicas
+// share one budget per key.
+func (rrl *redisRateLimits) Allow(ctx context.Context, key string, policy entities.RateLimitPolicy) (entities.RateLimitResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, rrl.timeout)
+	defer cancel()
+
+	burst := policy.Burst
+	if burst <= 0 {
+		burst = policy.Limit
+	}
+
+	res, err := rrl.limiter.Allow(ctx, "rate:"+key, redis_rate.Limit{
+		Rate:   policy.Limit,
+		Burst:  burst,
+		Period: policy.Period,
+	})
+	if err != nil {
+		return entities.RateLimitResult{}, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return entities.RateLimitResult{
+		Allowed:    res.Allowed > 0,
+		Remaining:  res.Remaining,
+		RetryAfter: res.RetryAfter,
+		ResetAfter: res.ResetAfter,
+	}, nil
+}
+
+func (rrl *redisRateLimits) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, rrl.timeout)
+	defer cancel()
+
+	ttl, err := rrl.client.PTTL(ctx, lockKey(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (rrl *redisRateLimits) RegisterFailure(ctx context.Context, key string, policy entities.LockoutPolicy) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, rrl.timeout)
+	defer cancel()
+
+	pipe := rrl.client.TxPipeline()
+	failures := pipe.Incr(ctx, failuresKey(key))
+	pipe.ExpireNX(ctx, failuresKey(key), policy.Window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	if failures.Val() < int64(policy.MaxFailures) {
+		return 0, nil
+	}
+
+	pipe = rrl.client.TxPipeline()
+	pipe.Set(ctx, lockKey(key), 1, policy.Duration)
+	pipe.Del(ctx, failuresKey(key))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return policy.Duration, nil
+}
+
+func (rrl *redisRateLimits) ResetFailures(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, rrl.timeout)
+	defer cancel()
+
+	if err := rrl.client.Del(ctx, failuresKey(key)).Err(); err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return nil
+}
+
+func failuresKey(key string) string {
+	return "login_failures:" + key
+}
+
+func lockKey(key string) string {
+	return "login_lock:" + key
+}
@@ -0,0 +1,153 @@
+package ratelimits
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/redis/go-redis/v9"
+)
+
+// The tests need a disposable Redis database, the keys they use are unique
+// per run:
+//
+//	TEST_REDIS_ADDR=localhost:6379 go test ./internal/infrastructure/repositories/rate_limits/
+const addrEnv = "TEST_REDIS_ADDR"
+
+func newTestRepository(t *testing.T) (*redisRateLimits, string) {
+	t.Helper()
+
+	addr := os.Getenv(addrEnv)
+	if addr == "" {
+		t.Skipf("%s is not set", addrEnv)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	prefix := fmt.Sprintf("test:%s:%d|", t.Name(), time.Now().UnixNano())
+	return NewRedisRepository(client, time.Second).(*redisRateLimits), prefix
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      entities.RateLimitPolicy
+		requests    int
+		wantAllowed int
+	}{
+		{"burst defaults to the limit", entities.RateLimitPolicy{Limit: 5, Period: time.Minute}, 8, 5},
+		{"smaller burst", entities.RateLimitPolicy{Limit: 60, Period: time.Minute, Burst: 3}, 8, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, key := newTestRepository(t)
+			ctx := context.Background()
+
+			allowed := 0
+			var last entities.RateLimitResult
+			for range tt.requests {
+				res, err := repo.Allow(ctx, key, tt.policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res.Allowed {
+					allowed++
+				}
+				last = res
+			}
+
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed %d of %d requests, want %d", allowed, tt.requests, tt.wantAllowed)
+			}
+			if last.Allowed || last.Remaining != 0 || last.RetryAfter <= 0 {
+				t.Errorf("got %+v for a request over the budget", last)
+			}
+		})
+	}
+}
+
+func TestAllowKeysAreSeparate(t *testing.T) {
+	repo, key := newTestRepository(t)
+	ctx := context.Background()
+	policy := entities.RateLimitPolicy{Limit: 1, Period: time.Minute}
+
+	for _, k := range []string{key + "ip:192.0.2.1", key + "ip:192.0.2.2"} {
+		res, err := repo.Allow(ctx, k, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Allowed {
+			t.Errorf("%s: denied its first request", k)
+		}
+	}
+}
+
+func TestLockout(t *testing.T) {
+	repo, key := newTestRepository(t)
+	ctx := context.Background()
+	policy := entities.LockoutPolicy{MaxFailures: 3, Window: time.Minute, Duration: time.Minute}
+
+	for i := 1; i < policy.MaxFailures; i++ {
+		lockedFor, err := repo.RegisterFailure(ctx, key, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lockedFor != 0 {
+			t.Fatalf("locked out after %d failures", i)
+		}
+	}
+
+	lockedFor, err := repo.RegisterFailure(ctx, key, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor != policy.Duration {
+		t.Errorf("got locked out for %s, want %s", lockedFor, policy.Duration)
+	}
+
+	lockedFor, err = repo.LockedFor(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor <= 0 || lockedFor > policy.Duration {
+		t.Errorf("got locked for %s, want up to %s", lockedFor, policy.Duration)
+	}
+}
+
+func TestResetFailures(t *testing.T) {
+	repo, key := newTestRepository(t)
+	ctx := context.Background()
+	policy := entities.LockoutPolicy{MaxFailures: 2, Window: time.Minute, Duration: time.Minute}
+
+	if _, err := repo.RegisterFailure(ctx, key, policy); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.ResetFailures(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	lockedFor, err := repo.RegisterFailure(ctx, key, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor != 0 {
+		t.Error("failures before a successful login still counted")
+	}
+
+	lockedFor, err = repo.LockedFor(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor != 0 {
+		t.Errorf("got locked for %s without a lockout", lockedFor)
+	}
+}
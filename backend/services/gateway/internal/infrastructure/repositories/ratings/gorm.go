@@ -59,6 +59,15 @@ func (gr *gormRatings) GetUserRatings(ctx context.Context, userID int) ([]entiti
 	return ratings, errorwrap.Wrap(ctx, err)
 }
 
+func (gr *gormRatings) DeleteUserRatings(ctx context.Context, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, gr.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&entities.Rating{}).Error)
+}
+
 func (gr *gormRatings) GetMovieRatings(ctx context.Context, movieID int) ([]entities.Rating, error) {
 	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
 	defer cancel()
@@ -69,3 +78,41 @@ func (gr *gormRatings) GetMovieRatings(ctx context.Context, movieID int) ([]enti
 		Find(&ratings).Error
 	return ratings, errorwrap.Wrap(ctx, err)
 }
+
+func (gr *gormRatings) GetByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Rating, error) {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	var ratings []entities.Rating
+	err := gr.db.WithContext(ctx).
+		Where("movie_id IN ?", movieIDs).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	byMovie := make(map[int][]entities.Rating, len(movieIDs))
+	for _, rating := range ratings {
+		byMovie[rating.MovieID] = append(byMovie[rating.MovieID], rating)
+	}
+	return byMovie, nil
+}
+
+func (gr *gormRatings) GetByUserIDs(ctx context.Context, userIDs []int) (map[int][]entities.Rating, error) {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	var ratings []entities.Rating
+	err := gr.db.WithContext(ctx).
+		Where("user_id IN ?", userIDs).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	byUser := make(map[int][]entities.Rating, len(userIDs))
+	for _, rating := range ratings {
+		byUser[rating.UserID] = append(byUser[rating.UserID], rating)
+	}
+	return byUser, nil
+}
@@ -0,0 +1,121 @@
+package requestlogs
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+)
+
+type gormAnalytics struct {
+	db *gorm.DB
+
+	timeout time.Duration
+}
+
+func NewGORMAnalytics(db *gorm.DB, timeout time.Duration) repositories.RequestLogAnalytics {
+	return &gormAnalytics{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (ga *gormAnalytics) Latency(ctx context.Context, from, to time.Time) ([]entities.PathLatency, error) {
+	ctx, cancel := context.WithTimeout(ctx, ga.timeout)
+	defer cancel()
+
+	var latencies []entities.PathLatency
+	err := ga.db.WithContext(ctx).Raw(`
+		SELECT
+			normalized_path AS path,
+			count() AS requests,
+			quantile(0.5)(duration_ms) AS p50_ms,
+			quantile(0.95)(duration_ms) AS p95_ms,
+			quantile(0.99)(duration_ms) AS p99_ms
+		FROM request_logs
+		WHERE timestamp >= ? AND timestamp < ?
+		GROUP BY normalized_path
+		ORDER BY p95_ms DESC
+	`, from, to).Scan(&latencies).Error
+	return latencies, errorwrap.Wrap(ctx, err)
+}
+
+func (ga *gormAnalytics) ErrorRates(ctx context.Context, from, to time.Time) ([]entities.StatusClassRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, ga.timeout)
+	defer cancel()
+
+	var rates []entities.StatusClassRate
+	err := ga.db.WithContext(ctx).Raw(`
+		SELECT status_class, count() AS requests
+		FROM request_logs
+		WHERE timestamp >= ? AND timestamp < ?
+		GROUP BY status_class
+		ORDER BY status_class
+	`, from, to).Scan(&rates).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	total := 0
+	for _, rate := range rates {
+		total += rate.Requests
+	}
+	for i := range rates {
+		rates[i].Rate = float64(rates[i].Requests) / float64(total)
+	}
+
+	return rates, nil
+}
+
+func (ga *gormAnalytics) TopUsers(ctx context.Context, from, to time.Time, limit int) ([]entities.UserRequests, error) {
+	ctx, cancel := context.WithTimeout(ctx, ga.timeout)
+	defer cancel()
+
+	var users []entities.UserRequests
+	err := ga.db.WithContext(ctx).Raw(`
+		SELECT user_id, count() AS requests
+		FROM request_logs
+		WHERE timestamp >= ? AND timestamp < ? AND user_id != 0
+		GROUP BY user_id
+		ORDER BY requests DESC
+		LIMIT ?
+	`, from, to, limit).Scan(&users).Error
+	return users, errorwrap.Wrap(ctx, err)
+}
+
+func (ga *gormAnalytics) DailyActiveUsers(ctx context.Context, from, to time.Time) ([]entities.DailyActiveUsers, error) {
+	ctx, cancel := context.WithTimeout(ctx, ga.timeout)
+	defer cancel()
+
+	var days []entities.DailyActiveUsers
+	err := ga.db.WithContext(ctx).Raw(`
+		SELECT toString(toDate(timestamp)) AS day, uniqExact(user_id) AS users
+		FROM request_logs
+		WHERE timestamp >= ? AND timestamp < ? AND user_id != 0
+		GROUP BY day
+		ORDER BY day
+	`, from, to).Scan(&days).Error
+	return days, errorwrap.Wrap(ctx, err)
+}
+
+func (ga *gormAnalytics) TimeSeries(ctx context.Context, from, to time.Time, bucket time.Duration, path string) ([]entities.RequestBucket, error) {
+	ctx, cancel := context.WithTimeout(ctx, ga.timeout)
+	defer cancel()
+
+	var buckets []entities.RequestBucket
+	err := ga.db.WithContext(ctx).Raw(`
+		SELECT
+			toStartOfInterval(timestamp, toIntervalSecond(?)) AS time,
+			count() AS requests,
+			countIf(status_class = '5xx') AS errors,
+			quantile(0.95)(duration_ms) AS p95_ms
+		FROM request_logs
+		WHERE timestamp >= ? AND timestamp < ? AND (? = '' OR normalized_path = ?)
+		GROUP BY time
+		ORDER BY time
+	`, int64(bucket.Seconds()), from, to, path, path).Scan(&buckets).Error
+	return buckets, errorwrap.Wrap(ctx, err)
+}
@@ -0,0 +1,48 @@
+package requestlogs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/batch"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+)
+
+// BatchWriter queues request logs and writes them to ClickHouse in batches,
+// which it handles far better than one row per insert. Reads go straight to
+// the database.
+type BatchWriter struct {
+	repositories.RequestLogs
+
+	*batch.Queue[*entities.RequestLog]
+}
+
+func NewBatchWriter(db *gorm.DB, cfg batch.Config, timeout time.Duration) *BatchWriter {
+	return &BatchWriter{
+		RequestLogs: NewGORMRepository(db, timeout),
+		Queue: batch.New("request_logs", cfg, func(ctx context.Context, logs []*entities.RequestLog) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return db.WithContext(ctx).Create(&logs).Error
+		}),
+	}
+}
+
+// Insert only queues the log, it is written by Run. A full queue is not
+// reported as an error, losing a log is preferable to failing the request.
+func (bw *BatchWriter) Insert(ctx context.Context, request *entities.RequestLog) error {
+	if request == nil {
+		return nil
+	}
+
+	if err := bw.Push(ctx, request); err != nil && !errors.Is(err, batch.ErrDropped) {
+		return errorwrap.Wrap(ctx, err)
+	}
+
+	return nil
+}
@@ -30,3 +30,15 @@ func (grl *gormRequestLogs) Insert(ctx context.Context, request *entities.Reques
 
 	return errorwrap.Wrap(ctx, grl.db.WithContext(ctx).Create(request).Error)
 }
+
+func (grl *gormRequestLogs) GetByUserID(ctx context.Context, userID int) ([]entities.RequestLog, error) {
+	ctx, cancel := context.WithTimeout(ctx, grl.timeout)
+	defer cancel()
+
+	var requestLogs []entities.RequestLog
+	err := grl.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("timestamp DESC").
+		Find(&requestLogs).Error
+	return requestLogs, errorwrap.Wrap(ctx, err)
+}
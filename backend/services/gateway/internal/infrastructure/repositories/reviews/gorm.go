@@ -41,6 +41,44 @@ func (gr *gormReviews) GetByUserID(ctx context.Context, userID int) ([]entities.
 	return reviews, errorwrap.Wrap(ctx, err)
 }
 
+func (gr *gormReviews) GetByMovieIDs(ctx context.Context, movieIDs []int) (map[int][]entities.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	var reviews []entities.Review
+	err := gr.db.WithContext(ctx).
+		Where("movie_id IN ?", movieIDs).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	byMovie := make(map[int][]entities.Review, len(movieIDs))
+	for _, review := range reviews {
+		byMovie[review.MovieID] = append(byMovie[review.MovieID], review)
+	}
+	return byMovie, nil
+}
+
+func (gr *gormReviews) GetByUserIDs(ctx context.Context, userIDs []int) (map[int][]entities.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	var reviews []entities.Review
+	err := gr.db.WithContext(ctx).
+		Where("user_id IN ?", userIDs).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, errorwrap.Wrap(ctx, err)
+	}
+
+	byUser := make(map[int][]entities.Review, len(userIDs))
+	for _, review := range reviews {
+		byUser[review.UserID] = append(byUser[review.UserID], review)
+	}
+	return byUser, nil
+}
+
 func (gr *gormReviews) CreateOrUpdateReview(ctx context.Context, review entities.Review) error {
 	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
 	defer cancel()
@@ -56,3 +94,12 @@ func (gr *gormReviews) DeleteReview(ctx context.Context, userID, movieID int) er
 		Where("user_id = ? AND movie_id = ?", userID, movieID).
 		Delete(&entities.Review{}).Error)
 }
+
+func (gr *gormReviews) DeleteUserReviews(ctx context.Context, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, gr.timeout)
+	defer cancel()
+
+	return errorwrap.Wrap(ctx, gr.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&entities.Review{}).Error)
+}
@@ -0,0 +1,43 @@
+package searchindexes
+
+import (
+	"context"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	errorwrap "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/repositories/error_wrap"
+	"gorm.io/gorm"
+)
+
+type gormSearchIndexes struct {
+	db *gorm.DB
+
+	timeout time.Duration
+}
+
+func NewGORMRepository(db *gorm.DB, timeout time.Duration) repositories.SearchIndexes {
+	return &gormSearchIndexes{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// Reindex rebuilds the trigram indexes used by title and name search without
+// blocking writes and refreshes planner statistics afterwards.
+func (gsi *gormSearchIndexes) Reindex(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, gsi.timeout)
+	defer cancel()
+
+	for _, statement := range []string{
+		"REINDEX INDEX CONCURRENTLY idx_movies_title_trgm",
+		"REINDEX INDEX CONCURRENTLY idx_actors_name_trgm",
+		"ANALYZE movies",
+		"ANALYZE actors",
+	} {
+		if err := gsi.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return errorwrap.Wrap(ctx, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package tokenrevocations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisRevocations struct {
+	client *redis.Client
+
+	mu    sync.RWMutex
+	local map[string]time.Time
+
+	timeout time.Duration
+}
+
+func NewRedisRepository(client *redis.Client, timeout time.Duration) repositories.TokenRevocations {
+	return &redisRevocations{
+		client:  client,
+		local:   make(map[string]time.Time),
+		timeout: timeout,
+	}
+}
+
+func (rr *redisRevocations) Revoke(ctx context.Context, token string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := revocationKey(token)
+
+	now := time.Now()
+
+	rr.mu.Lock()
+	for k, expiresAt := range rr.local {
+		if now.After(expiresAt) {
+			delete(rr.local, k)
+		}
+	}
+	rr.local[key] = now.Add(ttl)
+	rr.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, rr.timeout)
+	defer cancel()
+
+	if err := rr.client.Set(ctx, key, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return nil
+}
+
+// IsRevoked checks the in-process tier first and falls back to Redis so that
+// revocations made through other gateway replicas are also honoured.
+func (rr *redisRevocations) IsRevoked(ctx context.Context, token string) (bool, error) {
+	key := revocationKey(token)
+
+	rr.mu.RLock()
+	expiresAt, ok := rr.local[key]
+	rr.mu.RUnlock()
+
+	if ok {
+		if time.Now().Before(expiresAt) {
+			return true, nil
+		}
+
+		rr.mu.Lock()
+		delete(rr.local, key)
+		rr.mu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rr.timeout)
+	defer cancel()
+
+	exists, err := rr.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return exists > 0, nil
+}
+
+// TrackedSince returns when Redis started holding revocations. A flushed or
+// restarted Redis has lost the ones stored before, so the marker is written
+// again on the first call that does not find it.
+func (rr *redisRevocations) TrackedSince(ctx context.Context) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, rr.timeout)
+	defer cancel()
+
+	since, err := rr.client.Get(ctx, trackedSinceKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		now := time.Now().Unix()
+		if err = rr.client.SetNX(ctx, trackedSinceKey, now, 0).Err(); err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+		}
+
+		since, err = rr.client.Get(ctx, trackedSinceKey).Int64()
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return time.Unix(since, 0), nil
+}
+
+const trackedSinceKey = "revoked_token:tracked_since"
+
+func revocationKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "revoked_token:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,9 @@
+package trending
+
+import "time"
+
+type Config struct {
+	// CacheTTL is how long a computed ranking is reused before the buckets
+	// are merged again.
+	CacheTTL time.Duration
+}
@@ -0,0 +1,145 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+// granularity is a series of sorted sets, one per time bucket, holding the
+// activity score of every movie within the bucket.
+type granularity struct {
+	name   string
+	bucket time.Duration
+	keep   time.Duration
+}
+
+var (
+	minutes = granularity{name: "5m", bucket: 5 * time.Minute, keep: time.Hour}
+	hours   = granularity{name: "1h", bucket: time.Hour, keep: 7 * 24 * time.Hour}
+)
+
+// A window merges the buckets it spans, each weighted by 2^(-age/halfLife).
+type window struct {
+	granularity
+	span     time.Duration
+	halfLife time.Duration
+}
+
+var windows = map[entities.TrendingWindow]window{
+	entities.TrendingHour: {granularity: minutes, span: time.Hour, halfLife: 15 * time.Minute},
+	entities.TrendingDay:  {granularity: hours, span: 24 * time.Hour, halfLife: 6 * time.Hour},
+	entities.TrendingWeek: {granularity: hours, span: 7 * 24 * time.Hour, halfLife: 48 * time.Hour},
+}
+
+type redisTrending struct {
+	client *redis.Client
+	cfg    Config
+
+	timeout time.Duration
+}
+
+func NewRedisRepository(client *redis.Client, cfg Config, timeout time.Duration) repositories.Trending {
+	return &redisTrending{
+		client:  client,
+		cfg:     cfg,
+		timeout: timeout,
+	}
+}
+
+func (rt *redisTrending) Record(ctx context.Context, movieID int, weight float64) error {
+	ctx, cancel := context.WithTimeout(ctx, rt.timeout)
+	defer cancel()
+
+	now := time.Now()
+	member := strconv.Itoa(movieID)
+
+	_, err := rt.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, g := range []granularity{minutes, hours} {
+			key := bucketKey(g, now, 0)
+			pipe.ZIncrBy(ctx, key, weight, member)
+			pipe.Expire(ctx, key, g.keep+g.bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	return nil
+}
+
+func (rt *redisTrending) Top(ctx context.Context, name entities.TrendingWindow, limit int) ([]entities.MovieScore, error) {
+	w, ok := windows[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown trending window %q", repositories.ErrInvalidInput, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rt.timeout)
+	defer cancel()
+
+	key := "trending:top:" + string(name)
+
+	exists, err := rt.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	if exists == 0 {
+		if err := rt.merge(ctx, key, w); err != nil {
+			return nil, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+		}
+	}
+
+	top, err := rt.client.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrUnexpected, err.Error())
+	}
+
+	scores := make([]entities.MovieScore, 0, len(top))
+	for _, z := range top {
+		movieID, err := strconv.Atoi(z.Member.(string))
+		if err != nil {
+			continue
+		}
+		scores = append(scores, entities.MovieScore{MovieID: movieID, Score: z.Score})
+	}
+
+	return scores, nil
+}
+
+// merge stores the decayed union of the window buckets under key. Concurrent
+// merges only overwrite each other with the same result.
+func (rt *redisTrending) merge(ctx context.Context, key string, w window) error {
+	now := time.Now()
+	count := int(w.span / w.bucket)
+
+	store := &redis.ZStore{
+		Keys:      make([]string, 0, count),
+		Weights:   make([]float64, 0, count),
+		Aggregate: "SUM",
+	}
+	for age := range count {
+		store.Keys = append(store.Keys, bucketKey(w.granularity, now, age))
+		store.Weights = append(store.Weights, math.Exp2(-float64(time.Duration(age)*w.bucket)/float64(w.halfLife)))
+	}
+
+	_, err := rt.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, key, store)
+		pipe.Expire(ctx, key, rt.cfg.CacheTTL)
+		return nil
+	})
+	return err
+}
+
+// bucketKey names the bucket age buckets before the one now falls into.
+func bucketKey(g granularity, now time.Time, age int) string {
+	index := now.Unix()/int64(g.bucket.Seconds()) - int64(age)
+	return "trending:" + g.name + ":" + strconv.FormatInt(index, 10)
+}
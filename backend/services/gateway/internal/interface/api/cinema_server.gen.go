@@ -26,27 +26,63 @@ type ServerInterface interface {
 	// Get actor by ID
 	// (GET /actors/{id})
 	GetActorsId(c *gin.Context, id int)
+	// Daily active users
+	// (GET /admin/analytics/active-users)
+	GetAdminAnalyticsActiveUsers(c *gin.Context, params GetAdminAnalyticsActiveUsersParams)
+	// Share of requests per status class
+	// (GET /admin/analytics/errors)
+	GetAdminAnalyticsErrors(c *gin.Context, params GetAdminAnalyticsErrorsParams)
+	// Latency percentiles per route
+	// (GET /admin/analytics/latency)
+	GetAdminAnalyticsLatency(c *gin.Context, params GetAdminAnalyticsLatencyParams)
+	// Requests, errors and latency over time
+	// (GET /admin/analytics/timeseries)
+	GetAdminAnalyticsTimeseries(c *gin.Context, params GetAdminAnalyticsTimeseriesParams)
+	// Users with the most requests
+	// (GET /admin/analytics/top-users)
+	GetAdminAnalyticsTopUsers(c *gin.Context, params GetAdminAnalyticsTopUsersParams)
 	// Login and obtain JWT token
 	// (POST /login)
 	PostLogin(c *gin.Context)
 	// Logout from account
 	// (POST /logout)
 	PostLogout(c *gin.Context)
+	// Delete account of current user with all personal data
+	// (DELETE /me)
+	DeleteMe(c *gin.Context)
+	// Export personal data of current user
+	// (GET /me/export)
+	GetMeExport(c *gin.Context, params GetMeExportParams)
 	// Get popular movies
 	// (GET /movies/popular)
 	GetMoviesPopular(c *gin.Context, params GetMoviesPopularParams)
 	// Search movies by name
 	// (GET /movies/search)
 	GetMoviesSearch(c *gin.Context, params GetMoviesSearchParams)
+	// Get movies trending by recent local activity
+	// (GET /movies/trending)
+	GetMoviesTrending(c *gin.Context, params GetMoviesTrendingParams)
 	// Get movie by ID
 	// (GET /movies/{id})
 	GetMoviesId(c *gin.Context, id int)
+	// Record that the stream of a movie was opened
+	// (POST /movies/{id}/stream)
+	PostMoviesIdStream(c *gin.Context, id int)
+	// Start OpenID Connect login with external provider
+	// (GET /oidc/{provider}/authorize)
+	GetOidcProviderAuthorize(c *gin.Context, provider string)
+	// OpenID Connect redirect endpoint
+	// (GET /oidc/{provider}/callback)
+	GetOidcProviderCallback(c *gin.Context, provider string, params GetOidcProviderCallbackParams)
 	// Delete movie rate
 	// (DELETE /rating)
 	DeleteRating(c *gin.Context, params DeleteRatingParams)
 	// Rate a movie
 	// (POST /rating)
 	PostRating(c *gin.Context, params PostRatingParams)
+	// Exchange refresh token for a new token pair
+	// (POST /refresh)
+	PostRefresh(c *gin.Context)
 	// Register new account
 	// (POST /register)
 	PostRegister(c *gin.Context)
@@ -59,6 +95,15 @@ type ServerInterface interface {
 	// Create or update a review for a movie
 	// (POST /reviews/{movie_id})
 	PostReviewsMovieId(c *gin.Context, movieId int)
+	// Revoke all sessions of current user
+	// (DELETE /sessions)
+	DeleteSessions(c *gin.Context)
+	// List active sessions of current user
+	// (GET /sessions)
+	GetSessions(c *gin.Context)
+	// Revoke one session of current user
+	// (DELETE /sessions/{session_id})
+	DeleteSessionsSessionId(c *gin.Context, sessionId string)
 	// Get profile of user
 	// (GET /users/{id})
 	GetUsersId(c *gin.Context, id int)
@@ -130,6 +175,210 @@ func (siw *ServerInterfaceWrapper) GetActorsId(c *gin.Context) {
 	siw.Handler.GetActorsId(c, id)
 }
 
+// GetAdminAnalyticsActiveUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsActiveUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsActiveUsersParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsActiveUsers(c, params)
+}
+
+// GetAdminAnalyticsErrors operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsErrors(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsErrorsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsErrors(c, params)
+}
+
+// GetAdminAnalyticsLatency operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsLatency(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsLatencyParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsLatency(c, params)
+}
+
+// GetAdminAnalyticsTimeseries operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsTimeseries(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsTimeseriesParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bucket", c.Request.URL.Query(), &params.Bucket)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter bucket: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "path" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsTimeseries(c, params)
+}
+
+// GetAdminAnalyticsTopUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsTopUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsTopUsersParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsTopUsers(c, params)
+}
+
 // PostLogin operation middleware
 func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
 
@@ -160,6 +409,49 @@ func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
 	siw.Handler.PostLogout(c)
 }
 
+// DeleteMe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMe(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteMe(c)
+}
+
+// GetMeExport operation middleware
+func (siw *ServerInterfaceWrapper) GetMeExport(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMeExport(c, params)
+}
+
 // GetMoviesPopular operation middleware
 func (siw *ServerInterfaceWrapper) GetMoviesPopular(c *gin.Context) {
 
@@ -227,6 +519,40 @@ func (siw *ServerInterfaceWrapper) GetMoviesSearch(c *gin.Context) {
 	siw.Handler.GetMoviesSearch(c, params)
 }
 
+// GetMoviesTrending operation middleware
+func (siw *ServerInterfaceWrapper) GetMoviesTrending(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMoviesTrendingParams
+
+	// ------------- Optional query parameter "window" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "window", c.Request.URL.Query(), &params.Window)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter window: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMoviesTrending(c, params)
+}
+
 // GetMoviesId operation middleware
 func (siw *ServerInterfaceWrapper) GetMoviesId(c *gin.Context) {
 
@@ -251,6 +577,112 @@ func (siw *ServerInterfaceWrapper) GetMoviesId(c *gin.Context) {
 	siw.Handler.GetMoviesId(c, id)
 }
 
+// PostMoviesIdStream operation middleware
+func (siw *ServerInterfaceWrapper) PostMoviesIdStream(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostMoviesIdStream(c, id)
+}
+
+// GetOidcProviderAuthorize operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcProviderAuthorize(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcProviderAuthorize(c, provider)
+}
+
+// GetOidcProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcProviderCallback(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcProviderCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", c.Request.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := c.Query("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument state is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", c.Request.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcProviderCallback(c, provider, params)
+}
+
 // DeleteRating operation middleware
 func (siw *ServerInterfaceWrapper) DeleteRating(c *gin.Context) {
 
@@ -336,6 +768,19 @@ func (siw *ServerInterfaceWrapper) PostRating(c *gin.Context) {
 	siw.Handler.PostRating(c, params)
 }
 
+// PostRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRefresh(c)
+}
+
 // PostRegister operation middleware
 func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
 
@@ -425,6 +870,62 @@ func (siw *ServerInterfaceWrapper) PostReviewsMovieId(c *gin.Context) {
 	siw.Handler.PostReviewsMovieId(c, movieId)
 }
 
+// DeleteSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSessions(c)
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessions(c)
+}
+
+// DeleteSessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionsSessionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "session_id" -------------
+	var sessionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", c.Param("session_id"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter session_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSessionsSessionId(c, sessionId)
+}
+
 // GetUsersId operation middleware
 func (siw *ServerInterfaceWrapper) GetUsersId(c *gin.Context) {
 
@@ -478,46 +979,94 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/actors/search", wrapper.GetActorsSearch)
 	router.GET(options.BaseURL+"/actors/:id", wrapper.GetActorsId)
+	router.GET(options.BaseURL+"/admin/analytics/active-users", wrapper.GetAdminAnalyticsActiveUsers)
+	router.GET(options.BaseURL+"/admin/analytics/errors", wrapper.GetAdminAnalyticsErrors)
+	router.GET(options.BaseURL+"/admin/analytics/latency", wrapper.GetAdminAnalyticsLatency)
+	router.GET(options.BaseURL+"/admin/analytics/timeseries", wrapper.GetAdminAnalyticsTimeseries)
+	router.GET(options.BaseURL+"/admin/analytics/top-users", wrapper.GetAdminAnalyticsTopUsers)
 	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
 	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.DELETE(options.BaseURL+"/me", wrapper.DeleteMe)
+	router.GET(options.BaseURL+"/me/export", wrapper.GetMeExport)
 	router.GET(options.BaseURL+"/movies/popular", wrapper.GetMoviesPopular)
 	router.GET(options.BaseURL+"/movies/search", wrapper.GetMoviesSearch)
+	router.GET(options.BaseURL+"/movies/trending", wrapper.GetMoviesTrending)
 	router.GET(options.BaseURL+"/movies/:id", wrapper.GetMoviesId)
+	router.POST(options.BaseURL+"/movies/:id/stream", wrapper.PostMoviesIdStream)
+	router.GET(options.BaseURL+"/oidc/:provider/authorize", wrapper.GetOidcProviderAuthorize)
+	router.GET(options.BaseURL+"/oidc/:provider/callback", wrapper.GetOidcProviderCallback)
 	router.DELETE(options.BaseURL+"/rating", wrapper.DeleteRating)
 	router.POST(options.BaseURL+"/rating", wrapper.PostRating)
+	router.POST(options.BaseURL+"/refresh", wrapper.PostRefresh)
 	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
 	router.DELETE(options.BaseURL+"/reviews/:movie_id", wrapper.DeleteReviewsMovieId)
 	router.GET(options.BaseURL+"/reviews/:movie_id", wrapper.GetReviewsMovieId)
 	router.POST(options.BaseURL+"/reviews/:movie_id", wrapper.PostReviewsMovieId)
+	router.DELETE(options.BaseURL+"/sessions", wrapper.DeleteSessions)
+	router.GET(options.BaseURL+"/sessions", wrapper.GetSessions)
+	router.DELETE(options.BaseURL+"/sessions/:session_id", wrapper.DeleteSessionsSessionId)
 	router.GET(options.BaseURL+"/users/:id", wrapper.GetUsersId)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaUW/bNhD+KwS3hw0wYnftgMJvSbsGKbohSFr0oQiCs3Sy2Uikejw5cw3/94GkFFux",
-	"ZMtNsjhF3myRPB7v+3j3kdJcRibLjUbNVg7n0kYTzMD/PIzYkPuRk8mRWKF/PEYdo3/OsxzlUCrNOEaS",
-	"i55UcfNzDRmutFgmpceuISeTqBQvc+BJQ4dFr3piRl8xYjfkGDXhuls7Tt1k+W8zVQ2WwcUhzMGY+R+/",
-	"EiZyKH/pL4PXLyPXD2Fb2gcimLn/EBcpr7gyMiZF0K5p7NbUfYoQgoYp2oJgpkhThdfNGBjLSG0Q9CRh",
-	"imDxMgb20UkMZcByKP2DXtOAKeoCm12xTAjZZar0VeNsrDht5gpn8eiybYW+cWoYL2GKBOO6p0lqgJeu",
-	"6iIb3R4WmUJzs+3dzW622MS9M2C3zDXyZY6Traumm1HbfGqcEytO1OdM1RXGzUTd7A7jv7wjqIVFarG3",
-	"7rKjD0YFKZ6du60Q3D0Cq6LDIrDXbxHvtHu6jMOEOXfzHSEQUtV75P+9q2L3/vNH2Vsx4Vtv23BeKJ0Y",
-	"Nz5GG5HKWRkth/KN0piBOAbGa5iJw9MTebP0qvHcbcQIy8Ypkg1jXxwMDgZ+q+aoIVdyKF8eDA5eyp50",
-	"G9MvtB8yUd8iUDQJudjH24EHzomTWA7lMbLPQfY89HMWCDJkdFnsy1wqN+G3Amkmq/zoGJDljjeE3wpF",
-	"Dn+mAstoQFMGvXCdbW60DTj8MRj4dJnnqYq8O/2v1i1uvmLkLil00bsV8A/KsjCJSEyhY1Hm6UVP/hk8",
-	"iYxmDFtws1N1+iORoU4lY92jE81IGlLxlzfiGVtkGdBMDmXAo/RTjGbCx971qZCdq3ixHdeTuAVTn8Jv",
-	"IFVxFziX260Fz85R3IRoKK0NAfMrEjEyqNRj92rw6pGw+8eweOeYtMcUOkYO/HH0OXkbyJOasfLuuEq+",
-	"zptTY/mD73JHgOtrZHOF+gfXeF5EEVqbFKkIznvgXzxSxD9pKHhiSH3HPcS+LHh+l6+Uui8XbsMumeER",
-	"FqBjYUYMSov3nz+KgFFFElPwVpa4Ps00aUWQcKycgnwGsSOIKxKkAUVTsEjIZAKioCA9fF542X5u8iIF",
-	"2lQjfKq1p2XHbsXfCduNtaHXPNCq71sGPpxIKEtKZ5GQGcuijJ8I4RRKC7/2/U74dadrfNguBQMdnqwU",
-	"3BXlIAWrSO27FCxpWJOCJbLbpGDA9WeSgr7hWQrukhk8V1al4PJCIMYUw31NnTpv/fPyuqFTPrg5898H",
-	"heoLDG6I4Gv8uALikBmznAWb0h8Rgil4Aiy0YTEzBa0Ss3Et12B952Q/6dNViASalAQjYKxuCpuV48Pz",
-	"qUWBUDVxu6mtV2O7UNXC9NGJWupcP5PwlsRvOZkRjNJZkPxC6SmkKgbG+PenS8IzYBQQSFjmt+qssfEc",
-	"c1b1CrRAy0cmnt0hADlYe20obr3C7PyWYbG4TdXFHY5be1qaqvgLjdf1Ywz5K2fbn1dJYNGhVoUxXh50",
-	"1DsPWbK8N3tbsoJ3XUtW6P1zlSyogpAYWiaPXquQfmx6dY5yp/NS+U5n/cC0Fupy3UvUn+X2NrlNNyGr",
-	"MWtTIfo/uXUflW7Du7+d3+7dW/Ur89Q+aK9lxo0moMctGVcYEoYnSAIKngSN9nQT6xtCJ8QMiSKPoTXF",
-	"uvrutND2u4tPrteeXl3UQx6OF92/zCjPQQ2fZpS5486J/Af05vYzd4M2cBjtuzKo35KGT4mESYSLULBh",
-	"kaYVuQpKy5f4w34/NRGkE2N5+HrwetCHXMnFxeK/AAAA//8LjmKzDiUAAA==",
+	"H4sIAAAAAAAC/+xc3XPbuBH/VzBsH9opbcmO3btTn5zP5ibpZWxf7yH1aCByJeFMAjwAlKx49L93FgAp",
+	"UgRl8vyZxE+JSJBY7P72e+nrIBJpJjhwrYLRdZBRSVPQIM2vE06TlWaReitFihdiUJFkmWaCB6PgTFOp",
+	"iZgSPQciKZ9BSA6PyFzkUpEJTIUEcwt4TCYrEsOU5okOwoDh03/kIFdBGHCaQjAKprhFGKhoDinFvaZC",
+	"plQHoyCmGvY0SyEIA73KcLHSkvFZsF6HGxrPRZPCNzzeoo+L5c20aNGfknUYSFCZ4AoM795IKST+JxJc",
+	"A9f4X5plCYsoEjfIpJgkkP7jd4WUXle2+6uEaTAK/jLYiGZg76rBJ/uU3W/rrGZDvO4WGwlG2lKRSZGB",
+	"1MwSNwMeg7nujsG4hhnIYB0GLPZft7y53j54iK+esgTGGdVz74I8Q8bFY6p7yNVdEZPfIdL4lteUJauT",
+	"SLMF/KocQOuniumqsUHz3WGQF49vH9K37TvgEpp79eSS780fxYJ53kxRZHYPDam6CRFWwpv3UynpCn/T",
+	"GAG+IWUiRAKU460Znqn7FpYFni3amCAWIBcMln64CKVBtqNFQgJUwdhIryNe8KkF8Bz89CgtgabjhPFL",
+	"75aa6cSPbZ3Gk3HbMc3NhdAwpguQdFYnd5oIqjek8jydbD8WiZxr/7v760wY9CdlNxU+0H6iev6BauDR",
+	"qgnd7Hg4Tquatdkp++m4/dZPrbfaQfJHDkp31uLCbjY8xOnbV+SHH4c/EGePSQyaskSFJEoYagFRc5En",
+	"MZlIyqM5EZxEIkb2149uLo6uA+B5Gow+B4wvaMLisSM0CMsrjGc5/s45zfVcSPYF4iBEYU1YHAMPwoAL",
+	"PZ6KnON1STWME5YybZYlYsb4OBHRZeWnBDynuYBckJwmwYUHIPZsXoYyrjTlkV8LlKY6Vy1K0K47kkZQ",
+	"V57KTXPBZyeRY0xCjFw0d4s9SjpCy+0Lj5xPqcb3NJCZoq1tVWRZPnWTyviwdWpF/DKPLkE3twYphWxh",
+	"3g6t2IVw5EgKt3Gmp1DY5zqxCUNceZ3GbhZquNI9beufMXHoulto8B3zDJRigjfPGUnovXeUSwm8xaXG",
+	"sGAt2tOiASzzXk6o0mMFwG8j3jOjKK8SqtSpc6P180t3tSfsrAKOI3xxxygHY7XTyku36Ni5XS9pI3kQ",
+	"5ZLp1RkGLnaDl1Sx6CS3bsQENEZweHXDyrnWGe73EqgEWayemF9vCxH8/Nt5kRWYV5i72+9YG1M69aQi",
+	"rxiHlJJ3VMOSrsjJp/elYStvnmHYFIG7uQBpwRsc7A/3h0igyIDTjAWj4MX+cP9FYD2kOejAxo0DBVRG",
+	"cxvkG6wiu03K8T4ORsE70CZiVGd2XVhL+T5fe/OhTIo000HVNmuZQzVHSunVB+Az5NzhcBgGKePF74Mm",
+	"ZC+2kqXD4XBHqtRMkW4TGTczpw9MmTTWuFxi+YjPvRgeNeX4H6HJRxGzKYMYFx3vJP3Os7z3zr+TSrqX",
+	"pymVK0zIjUzdCTDNNfLDNQU6rlm8vhkb7+MWXJh4rIQFi3dCoqGyt5X6LpbZZMrDMHOiIqrrLNWj4dEO",
+	"0u5cqrj9WxPwPTFEvQNt4YRoev/aYSlOGR/QovCC2GIL2CvTageu+ja/8GRF6IKyhE4SIFoQ8xqmtKSI",
+	"uf0g9MARl5QVnmry38Cn7/SbJYN6KWsddn/gXAQPY68aBY4Opss8Q6wASF48dTQctm1WHmPgxI2rD3qt",
+	"ftFj9XEPSioO3Ei06o0/X6wvqrj0ndsHzU3wfV+gfGN3+AbxuB1EdoDj2ZxKQFdaxHUkA0ls1Ehs1Phd",
+	"wLMLH3xwTTaVlfvCa1G8+QYBW61NdQn77FIUTQRcswSsmKTINXwfOL2BBT6IYhKqQJaNhPtB6flml4cF",
+	"arh9GFvWIYp9AUIVoSTOLeEhgf3ZPjlOiZDkYN7SSpqYxwNPXLxJmr38M1XZjf3Qgug5U04y/r1cdN6+",
+	"04NoYb0c1kEP3QMqJNZbE8pj4iwhwVYCQcx9HwrZlRdezRTZ/cfg5yJ7jAA89BcmTF082KpDsDRPg9GB",
+	"q0K4X+Hdp6OdtKFW/uqgDIa5ZMn03HStU6E2RuD7UIHdHEDgm6YH7p0J5SljfBJKfzBLbingbh1fCVMJ",
+	"aj7W4hJ4ZUmlBN5yx1PDbEbUeRSBUtM8IfbYG6k+VIHg12qj6mnUJ2pY2lR4G+ENcsyYUDHRlHHy82/n",
+	"xEqjAJLI9Y1IErlugVKrrCTMmNIgn8XVKxw1vCY4CkRoZPvSRlC22RVDAho8PVxYiEtQBBYgV0TZno8R",
+	"O9Ib5xjVTmh0OZOmwAuSqrxIz7ANpkJiO4DW3aLVcbsTphUk0/3/8YZnfG2I+QhNZBzewsgoDf62UL9W",
+	"SLNOhNQiVwqOxM/I7FVvMtIuYSGmxHUDTfHJeiuaJJg8KYF7xFTTArsDuMqE1Lvq7h/hjV3TqR/j2oLV",
+	"uKeYOzDcCoMvLPNMANw65Gk0EVFpumcGZr1vkMj593Eitl7XaCc2nzQa3J0Gs973ph0aZm92Hu1ahzU2",
+	"frGdXs884YRxauTqGSbc6mLIaI6lTgM0r9VyLCRzprSQq2f17qPeVvnq2rut5E6bsc+kBpnI8oTKWqaV",
+	"SYio3nTCtp1UltAIzCQsepiMziAmhTbiZgOascHicGsLb05mul3qkyOiWw+XzqCeKe3KjVryLayCPOl0",
+	"y7UBu/d8TXrhWE0s5wnjRjpfZQv4HWwfJwiDq70NOveM5TkcHv5z72C4d/CTua1s5CpkMAocDN11rsCt",
+	"/2FveITrq2pw87CBReq3P2zQG3h22MCJ6CseNnA6Uxs2cOjQEnjsBuu89aj/Wjdmx3OJyIA3nJrxdK4Y",
+	"qsWSylgZ86kiISEkqZBAJERopE03kOkVWQKbzRmf2bt6TjkRSQySCA6+UL6E6XlBcCegLhmPxbJmDovP",
+	"CkZmFj0sozL8JCII3cUlwKUvOPtqa1ydsV/w14EmtNbXdXGnTCrdY0BjeI+1qpo5dQgv0IxQd4hLREST",
+	"Enc17N80aGMB9y0N2pgbz4M2t/DbBjrVQZsKlgbWSO6uVRWgOrNrHwZaR76CTCRkXBPwfSuq3RKNvbb+",
+	"wfmUKaGOr0uqjIuB2DJXsDgaXGdSLFgMcj0ok5dWd3UKOpdckeIZUjxipEB+Pf2wT36bAycRTRKIXVWA",
+	"2ElRW3cMDXFw5ZDAYuAaXRZTBL9Ogdg2+qDMPIqKA51qkObFWMnaJ2eg0UWSf2udmbYOHmesNNVAIiEu",
+	"GTheGNM1B1s9JlRrSDNdthMnUiwVyH/ZPd3bkRoJ01y5M2BNjukWz/kLi6NPjiEnJQu7QK9gYxcA3lMF",
+	"oybAcS6TP1mlP9kGAjJYgSsmOiYTLR7B4hWyQaFyoUkk+JTNcvkEA0rzOekvGfD3r8krwTlE2sHWaFKp",
+	"NSVwvHpcoHiX962C9lWx/t4w2xLYue+Iej9nlPwuCDAt5uDhtO3pdNBe2CL97oUhkRAziSjUApsSeGhn",
+	"1Q1BplIwlXSWIjMevuBm21vo14oPwEJiPQBTJOeXXCw5DqfgCqZUbr0L5ULPQRZW6alZgS39L0UAPM4E",
+	"KxpCmy+2Nk0hX3/GlZw75XPlB053EWtvRQ6GDGJpfYzWy8nG71siXJptgwT0CyuRS1XxT94DIJJw8fQJ",
+	"hdA9Wzg2GJRuoLU9lr5/5LRYZVls3P6qxleKlYpApSAwbH6+2Aetii4eB6u1YMo4KfI33IVOkpW1vMR9",
+	"TIsFzb9/ZTjEWeoiKXHGzPq/3bndqVtUNsteinh1m+bdttPdXUStf5dbf/jC64Xr8F1/A5HEuXX6UqCP",
+	"fQzNcBgodEAVahASuMqQ14W3p4kEGq+wb/Xkwvw3V9Gc8hkQWTvNVEhCCYel+51RJgv1cLM0N+iHW3VX",
+	"CpJRpZZCxjfqRr073EeNyufCzW63UKZO00hPCQqFzIzYawM/rvA/uC5c67pDrGefMUWwjoXV+wz5DDVP",
+	"K+SzJHUN+ezqbyDko8XJrY1Ji3ZFW3XgsYF01/P4/okXn3ux3bZS1M8V/J4VfFlysAa0XV7rIaF2F25x",
+	"x18IKf4GSKU9fzAcDofhjr8KUm3lHx+HvdynJaV4mdv/zvyns3+PlgVtzHcZL3nMN0Z8tp6DtWybLX1l",
+	"VvqV+VsseBD7F2Fa7DWGBW62V90cDJwVKzuFSm4x7isun2dj+36zhEwz46+FfJqzc+3e9gZJ3fE3zXaz",
+	"Lt7QfolfHukZE70m+dlmsKMdFFWVHly7/3UL9gvUuH87+s7NFnfRdPQakSdkQ7xhfUHm1xzXO4sjeAku",
+	"P7bwfzeP4pgPzp7oJM79Ddzf5dh898n4Zs7R6Iz0STqa304+SVzXp3Ptn8tFxDqk4hKQiwJ2ZgzB/D2x",
+	"0WBgRszmQunRj8Mfh3Y29yBYX6z/PwD0MrPBulkAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,298 @@
+// Package apiv2 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package apiv2
+
+import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BasicAuthScopes  = "BasicAuth.Scopes"
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for ProblemCode.
+const (
+	Forbidden      ProblemCode = "forbidden"
+	Internal       ProblemCode = "internal"
+	InvalidInput   ProblemCode = "invalid_input"
+	InvalidRequest ProblemCode = "invalid_request"
+	LoginLocked    ProblemCode = "login_locked"
+	LoginRejected  ProblemCode = "login_rejected"
+	NotFound       ProblemCode = "not_found"
+	RateLimited    ProblemCode = "rate_limited"
+	Unauthorized   ProblemCode = "unauthorized"
+)
+
+// Defines values for GetMeExportParamsFormat.
+const (
+	Json GetMeExportParamsFormat = "json"
+	Zip  GetMeExportParamsFormat = "zip"
+)
+
+// Defines values for GetMoviesTrendingParamsWindow.
+const (
+	Day  GetMoviesTrendingParamsWindow = "day"
+	Hour GetMoviesTrendingParamsWindow = "hour"
+	Week GetMoviesTrendingParamsWindow = "week"
+)
+
+// Actor defines model for Actor.
+type Actor struct {
+	Gender      *int       `json:"gender,omitempty"`
+	Id          *int       `json:"id,omitempty"`
+	Name        *string    `json:"name,omitempty"`
+	ProfilePath *string    `json:"profile_path,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// DailyActiveUsers defines model for DailyActiveUsers.
+type DailyActiveUsers struct {
+	Day   *openapi_types.Date `json:"day,omitempty"`
+	Users *int                `json:"users,omitempty"`
+}
+
+// Genre defines model for Genre.
+type Genre struct {
+	Id   *int    `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// Movie defines model for Movie.
+type Movie struct {
+	Actors          *[]Actor   `json:"actors,omitempty"`
+	Adult           *bool      `json:"adult,omitempty"`
+	Genres          *[]Genre   `json:"genres,omitempty"`
+	Id              *int       `json:"id,omitempty"`
+	Overview        *string    `json:"overview,omitempty"`
+	PosterPath      *string    `json:"poster_path,omitempty"`
+	ReleaseDate     *time.Time `json:"release_date,omitempty"`
+	Revenue         *int       `json:"revenue,omitempty"`
+	StreamLink      *string    `json:"stream_link,omitempty"`
+	Title           *string    `json:"title,omitempty"`
+	TmdbId          *int       `json:"tmdb_id,omitempty"`
+	TmdbVoteAverage *float32   `json:"tmdb_vote_average,omitempty"`
+	TmdbVoteCount   *int       `json:"tmdb_vote_count,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+	VoteAverage     *float32   `json:"vote_average,omitempty"`
+	VoteCount       *int       `json:"vote_count,omitempty"`
+}
+
+// MoviePage defines model for MoviePage.
+type MoviePage struct {
+	Items []Movie `json:"items"`
+	Page  int     `json:"page"`
+	Size  int     `json:"size"`
+}
+
+// PathLatency defines model for PathLatency.
+type PathLatency struct {
+	P50Ms    *float32 `json:"p50_ms,omitempty"`
+	P95Ms    *float32 `json:"p95_ms,omitempty"`
+	P99Ms    *float32 `json:"p99_ms,omitempty"`
+	Path     *string  `json:"path,omitempty"`
+	Requests *int     `json:"requests,omitempty"`
+}
+
+// Problem RFC 7807 problem details, clients should branch on code
+type Problem struct {
+	Code     ProblemCode `json:"code"`
+	Detail   *string     `json:"detail,omitempty"`
+	Instance *string     `json:"instance,omitempty"`
+	Status   int         `json:"status"`
+	Title    string      `json:"title"`
+	TraceId  *string     `json:"trace_id,omitempty"`
+	Type     string      `json:"type"`
+}
+
+// ProblemCode defines model for Problem.Code.
+type ProblemCode string
+
+// Rating defines model for Rating.
+type Rating struct {
+	MovieId *int     `json:"movie_id,omitempty"`
+	Rating  *float32 `json:"rating,omitempty"`
+}
+
+// RequestBucket defines model for RequestBucket.
+type RequestBucket struct {
+	Errors   *int       `json:"errors,omitempty"`
+	P95Ms    *float32   `json:"p95_ms,omitempty"`
+	Requests *int       `json:"requests,omitempty"`
+	Time     *time.Time `json:"time,omitempty"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	Liked     *bool      `json:"liked,omitempty"`
+	MovieId   *int       `json:"movie_id,omitempty"`
+	Text      *string    `json:"text,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserId    *int       `json:"user_id,omitempty"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Current   *bool      `json:"current,omitempty"`
+	Device    *string    `json:"device,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+	Ip        *string    `json:"ip,omitempty"`
+	LastSeen  *time.Time `json:"last_seen,omitempty"`
+}
+
+// StatusClassRate defines model for StatusClassRate.
+type StatusClassRate struct {
+	Rate        *float32 `json:"rate,omitempty"`
+	Requests    *int     `json:"requests,omitempty"`
+	StatusClass *string  `json:"status_class,omitempty"`
+}
+
+// UserRequests defines model for UserRequests.
+type UserRequests struct {
+	Requests *int `json:"requests,omitempty"`
+	UserId   *int `json:"user_id,omitempty"`
+}
+
+// AnalyticsFrom defines model for AnalyticsFrom.
+type AnalyticsFrom = time.Time
+
+// AnalyticsTo defines model for AnalyticsTo.
+type AnalyticsTo = time.Time
+
+// Error RFC 7807 problem details, clients should branch on code
+type Error = Problem
+
+// GetActorsSearchParams defines parameters for GetActorsSearch.
+type GetActorsSearchParams struct {
+	Prompt string `form:"prompt" json:"prompt"`
+}
+
+// GetAdminAnalyticsActiveUsersParams defines parameters for GetAdminAnalyticsActiveUsers.
+type GetAdminAnalyticsActiveUsersParams struct {
+	// From Start of the range, 24 hours before the end by default
+	From *AnalyticsFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, now by default
+	To *AnalyticsTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetAdminAnalyticsErrorsParams defines parameters for GetAdminAnalyticsErrors.
+type GetAdminAnalyticsErrorsParams struct {
+	// From Start of the range, 24 hours before the end by default
+	From *AnalyticsFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, now by default
+	To *AnalyticsTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetAdminAnalyticsLatencyParams defines parameters for GetAdminAnalyticsLatency.
+type GetAdminAnalyticsLatencyParams struct {
+	// From Start of the range, 24 hours before the end by default
+	From *AnalyticsFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, now by default
+	To *AnalyticsTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetAdminAnalyticsTimeseriesParams defines parameters for GetAdminAnalyticsTimeseries.
+type GetAdminAnalyticsTimeseriesParams struct {
+	// From Start of the range, 24 hours before the end by default
+	From *AnalyticsFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, now by default
+	To *AnalyticsTo `form:"to,omitempty" json:"to,omitempty"`
+
+	// Bucket Bucket size as a duration, e.g. 5m or 1h
+	Bucket *string `form:"bucket,omitempty" json:"bucket,omitempty"`
+
+	// Path Only count requests to this route
+	Path *string `form:"path,omitempty" json:"path,omitempty"`
+}
+
+// GetAdminAnalyticsTopUsersParams defines parameters for GetAdminAnalyticsTopUsers.
+type GetAdminAnalyticsTopUsersParams struct {
+	// From Start of the range, 24 hours before the end by default
+	From *AnalyticsFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, now by default
+	To    *AnalyticsTo `form:"to,omitempty" json:"to,omitempty"`
+	Limit *int         `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMeExportParams defines parameters for GetMeExport.
+type GetMeExportParams struct {
+	Format *GetMeExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetMeExportParamsFormat defines parameters for GetMeExport.
+type GetMeExportParamsFormat string
+
+// GetMoviesPopularParams defines parameters for GetMoviesPopular.
+type GetMoviesPopularParams struct {
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// GetMoviesSearchParams defines parameters for GetMoviesSearch.
+type GetMoviesSearchParams struct {
+	Prompt string `form:"prompt" json:"prompt"`
+}
+
+// GetMoviesTrendingParams defines parameters for GetMoviesTrending.
+type GetMoviesTrendingParams struct {
+	Window *GetMoviesTrendingParamsWindow `form:"window,omitempty" json:"window,omitempty"`
+	Limit  *int                           `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMoviesTrendingParamsWindow defines parameters for GetMoviesTrending.
+type GetMoviesTrendingParamsWindow string
+
+// GetOidcProviderCallbackParams defines parameters for GetOidcProviderCallback.
+type GetOidcProviderCallbackParams struct {
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	State string  `form:"state" json:"state"`
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// DeleteRatingParams defines parameters for DeleteRating.
+type DeleteRatingParams struct {
+	MovieId int `form:"movie_id" json:"movie_id"`
+}
+
+// PostRatingParams defines parameters for PostRating.
+type PostRatingParams struct {
+	MovieId int     `form:"movie_id" json:"movie_id"`
+	Rating  float32 `form:"rating" json:"rating"`
+}
+
+// PostRefreshJSONBody defines parameters for PostRefresh.
+type PostRefreshJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PostRegisterJSONBody defines parameters for PostRegister.
+type PostRegisterJSONBody struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// PostReviewsMovieIdJSONBody defines parameters for PostReviewsMovieId.
+type PostReviewsMovieIdJSONBody struct {
+	Liked bool   `json:"liked"`
+	Text  string `json:"text"`
+	Title string `json:"title"`
+}
+
+// PostRefreshJSONRequestBody defines body for PostRefresh for application/json ContentType.
+type PostRefreshJSONRequestBody PostRefreshJSONBody
+
+// PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
+type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PostReviewsMovieIdJSONRequestBody defines body for PostReviewsMovieId for application/json ContentType.
+type PostReviewsMovieIdJSONRequestBody PostReviewsMovieIdJSONBody
@@ -0,0 +1,1141 @@
+// Package apiv2 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package apiv2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Search actors by name
+	// (GET /actors/search)
+	GetActorsSearch(c *gin.Context, params GetActorsSearchParams)
+	// Get actor by ID
+	// (GET /actors/{id})
+	GetActorsId(c *gin.Context, id int)
+	// Daily active users
+	// (GET /admin/analytics/active-users)
+	GetAdminAnalyticsActiveUsers(c *gin.Context, params GetAdminAnalyticsActiveUsersParams)
+	// Share of requests per status class
+	// (GET /admin/analytics/errors)
+	GetAdminAnalyticsErrors(c *gin.Context, params GetAdminAnalyticsErrorsParams)
+	// Latency percentiles per route
+	// (GET /admin/analytics/latency)
+	GetAdminAnalyticsLatency(c *gin.Context, params GetAdminAnalyticsLatencyParams)
+	// Requests, errors and latency over time
+	// (GET /admin/analytics/timeseries)
+	GetAdminAnalyticsTimeseries(c *gin.Context, params GetAdminAnalyticsTimeseriesParams)
+	// Users with the most requests
+	// (GET /admin/analytics/top-users)
+	GetAdminAnalyticsTopUsers(c *gin.Context, params GetAdminAnalyticsTopUsersParams)
+	// Login and obtain JWT token
+	// (POST /login)
+	PostLogin(c *gin.Context)
+	// Logout from account
+	// (POST /logout)
+	PostLogout(c *gin.Context)
+	// Delete account of current user with all personal data
+	// (DELETE /me)
+	DeleteMe(c *gin.Context)
+	// Export personal data of current user
+	// (GET /me/export)
+	GetMeExport(c *gin.Context, params GetMeExportParams)
+	// Get popular movies
+	// (GET /movies/popular)
+	GetMoviesPopular(c *gin.Context, params GetMoviesPopularParams)
+	// Search movies by name
+	// (GET /movies/search)
+	GetMoviesSearch(c *gin.Context, params GetMoviesSearchParams)
+	// Get movies trending by recent local activity
+	// (GET /movies/trending)
+	GetMoviesTrending(c *gin.Context, params GetMoviesTrendingParams)
+	// Get movie by ID
+	// (GET /movies/{id})
+	GetMoviesId(c *gin.Context, id int)
+	// Record that the stream of a movie was opened
+	// (POST /movies/{id}/stream)
+	PostMoviesIdStream(c *gin.Context, id int)
+	// Start OpenID Connect login with external provider
+	// (GET /oidc/{provider}/authorize)
+	GetOidcProviderAuthorize(c *gin.Context, provider string)
+	// OpenID Connect redirect endpoint
+	// (GET /oidc/{provider}/callback)
+	GetOidcProviderCallback(c *gin.Context, provider string, params GetOidcProviderCallbackParams)
+	// Delete movie rate
+	// (DELETE /rating)
+	DeleteRating(c *gin.Context, params DeleteRatingParams)
+	// Rate a movie
+	// (POST /rating)
+	PostRating(c *gin.Context, params PostRatingParams)
+	// Exchange refresh token for a new token pair
+	// (POST /refresh)
+	PostRefresh(c *gin.Context)
+	// Register new account
+	// (POST /register)
+	PostRegister(c *gin.Context)
+	// Delete a review for a movie
+	// (DELETE /reviews/{movie_id})
+	DeleteReviewsMovieId(c *gin.Context, movieId int)
+	// Get reviews for a movie
+	// (GET /reviews/{movie_id})
+	GetReviewsMovieId(c *gin.Context, movieId int)
+	// Create or update a review for a movie
+	// (POST /reviews/{movie_id})
+	PostReviewsMovieId(c *gin.Context, movieId int)
+	// Revoke all sessions of current user
+	// (DELETE /sessions)
+	DeleteSessions(c *gin.Context)
+	// List active sessions of current user
+	// (GET /sessions)
+	GetSessions(c *gin.Context)
+	// Revoke one session of current user
+	// (DELETE /sessions/{session_id})
+	DeleteSessionsSessionId(c *gin.Context, sessionId string)
+	// Get profile of user
+	// (GET /users/{id})
+	GetUsersId(c *gin.Context, id int)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetActorsSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetActorsSearch(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetActorsSearchParams
+
+	// ------------- Required query parameter "prompt" -------------
+
+	if paramValue := c.Query("prompt"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument prompt is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "prompt", c.Request.URL.Query(), &params.Prompt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prompt: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetActorsSearch(c, params)
+}
+
+// GetActorsId operation middleware
+func (siw *ServerInterfaceWrapper) GetActorsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetActorsId(c, id)
+}
+
+// GetAdminAnalyticsActiveUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsActiveUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsActiveUsersParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsActiveUsers(c, params)
+}
+
+// GetAdminAnalyticsErrors operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsErrors(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsErrorsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsErrors(c, params)
+}
+
+// GetAdminAnalyticsLatency operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsLatency(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsLatencyParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsLatency(c, params)
+}
+
+// GetAdminAnalyticsTimeseries operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsTimeseries(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsTimeseriesParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bucket", c.Request.URL.Query(), &params.Bucket)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter bucket: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "path" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsTimeseries(c, params)
+}
+
+// GetAdminAnalyticsTopUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAnalyticsTopUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAnalyticsTopUsersParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminAnalyticsTopUsers(c, params)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+
+	c.Set(BasicAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogin(c)
+}
+
+// PostLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogout(c)
+}
+
+// DeleteMe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMe(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteMe(c)
+}
+
+// GetMeExport operation middleware
+func (siw *ServerInterfaceWrapper) GetMeExport(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMeExport(c, params)
+}
+
+// GetMoviesPopular operation middleware
+func (siw *ServerInterfaceWrapper) GetMoviesPopular(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMoviesPopularParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMoviesPopular(c, params)
+}
+
+// GetMoviesSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetMoviesSearch(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMoviesSearchParams
+
+	// ------------- Required query parameter "prompt" -------------
+
+	if paramValue := c.Query("prompt"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument prompt is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "prompt", c.Request.URL.Query(), &params.Prompt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prompt: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMoviesSearch(c, params)
+}
+
+// GetMoviesTrending operation middleware
+func (siw *ServerInterfaceWrapper) GetMoviesTrending(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMoviesTrendingParams
+
+	// ------------- Optional query parameter "window" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "window", c.Request.URL.Query(), &params.Window)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter window: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMoviesTrending(c, params)
+}
+
+// GetMoviesId operation middleware
+func (siw *ServerInterfaceWrapper) GetMoviesId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMoviesId(c, id)
+}
+
+// PostMoviesIdStream operation middleware
+func (siw *ServerInterfaceWrapper) PostMoviesIdStream(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostMoviesIdStream(c, id)
+}
+
+// GetOidcProviderAuthorize operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcProviderAuthorize(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcProviderAuthorize(c, provider)
+}
+
+// GetOidcProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcProviderCallback(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcProviderCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", c.Request.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := c.Query("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument state is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", c.Request.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcProviderCallback(c, provider, params)
+}
+
+// DeleteRating operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRating(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRatingParams
+
+	// ------------- Required query parameter "movie_id" -------------
+
+	if paramValue := c.Query("movie_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument movie_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "movie_id", c.Request.URL.Query(), &params.MovieId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter movie_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRating(c, params)
+}
+
+// PostRating operation middleware
+func (siw *ServerInterfaceWrapper) PostRating(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRatingParams
+
+	// ------------- Required query parameter "movie_id" -------------
+
+	if paramValue := c.Query("movie_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument movie_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "movie_id", c.Request.URL.Query(), &params.MovieId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter movie_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "rating" -------------
+
+	if paramValue := c.Query("rating"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument rating is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "rating", c.Request.URL.Query(), &params.Rating)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rating: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRating(c, params)
+}
+
+// PostRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRefresh(c)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRegister(c)
+}
+
+// DeleteReviewsMovieId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReviewsMovieId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "movie_id" -------------
+	var movieId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "movie_id", c.Param("movie_id"), &movieId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter movie_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteReviewsMovieId(c, movieId)
+}
+
+// GetReviewsMovieId operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsMovieId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "movie_id" -------------
+	var movieId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "movie_id", c.Param("movie_id"), &movieId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter movie_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReviewsMovieId(c, movieId)
+}
+
+// PostReviewsMovieId operation middleware
+func (siw *ServerInterfaceWrapper) PostReviewsMovieId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "movie_id" -------------
+	var movieId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "movie_id", c.Param("movie_id"), &movieId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter movie_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReviewsMovieId(c, movieId)
+}
+
+// DeleteSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSessions(c)
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessions(c)
+}
+
+// DeleteSessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionsSessionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "session_id" -------------
+	var sessionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", c.Param("session_id"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter session_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSessionsSessionId(c, sessionId)
+}
+
+// GetUsersId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersId(c, id)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/actors/search", wrapper.GetActorsSearch)
+	router.GET(options.BaseURL+"/actors/:id", wrapper.GetActorsId)
+	router.GET(options.BaseURL+"/admin/analytics/active-users", wrapper.GetAdminAnalyticsActiveUsers)
+	router.GET(options.BaseURL+"/admin/analytics/errors", wrapper.GetAdminAnalyticsErrors)
+	router.GET(options.BaseURL+"/admin/analytics/latency", wrapper.GetAdminAnalyticsLatency)
+	router.GET(options.BaseURL+"/admin/analytics/timeseries", wrapper.GetAdminAnalyticsTimeseries)
+	router.GET(options.BaseURL+"/admin/analytics/top-users", wrapper.GetAdminAnalyticsTopUsers)
+	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
+	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.DELETE(options.BaseURL+"/me", wrapper.DeleteMe)
+	router.GET(options.BaseURL+"/me/export", wrapper.GetMeExport)
+	router.GET(options.BaseURL+"/movies/popular", wrapper.GetMoviesPopular)
+	router.GET(options.BaseURL+"/movies/search", wrapper.GetMoviesSearch)
+	router.GET(options.BaseURL+"/movies/trending", wrapper.GetMoviesTrending)
+	router.GET(options.BaseURL+"/movies/:id", wrapper.GetMoviesId)
+	router.POST(options.BaseURL+"/movies/:id/stream", wrapper.PostMoviesIdStream)
+	router.GET(options.BaseURL+"/oidc/:provider/authorize", wrapper.GetOidcProviderAuthorize)
+	router.GET(options.BaseURL+"/oidc/:provider/callback", wrapper.GetOidcProviderCallback)
+	router.DELETE(options.BaseURL+"/rating", wrapper.DeleteRating)
+	router.POST(options.BaseURL+"/rating", wrapper.PostRating)
+	router.POST(options.BaseURL+"/refresh", wrapper.PostRefresh)
+	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
+	router.DELETE(options.BaseURL+"/reviews/:movie_id", wrapper.DeleteReviewsMovieId)
+	router.GET(options.BaseURL+"/reviews/:movie_id", wrapper.GetReviewsMovieId)
+	router.POST(options.BaseURL+"/reviews/:movie_id", wrapper.PostReviewsMovieId)
+	router.DELETE(options.BaseURL+"/sessions", wrapper.DeleteSessions)
+	router.GET(options.BaseURL+"/sessions", wrapper.GetSessions)
+	router.DELETE(options.BaseURL+"/sessions/:session_id", wrapper.DeleteSessionsSessionId)
+	router.GET(options.BaseURL+"/users/:id", wrapper.GetUsersId)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xcWXPbOPL/Kij+/w+7tRxLOVwzo31yzs1UsuOyPTsPsykVRLYkjEmAAUApikvffatx",
+	"UKQIStT4TOKnRCSORvevD3Q3fRUlIi8EB65VNLqKCippDhqk+XXCabbSLFFvpMjxQQoqkazQTPBoFJ1r",
+	"KjURU6LnQCTlM4jJ0+dkLkqpyASmQoJ5BTwlkxVJYUrLTEdxxHD2pxLkKoojTnOIRtEUt4gjlcwhp7jX",
+	"VMic6mgUpVTDD5rlEMWRXhU4WGnJ+Cxar+MNjReiTeFrnm7Rx8VyPy1aHE7JOo4kqEJwBYZ3r6UUEv+T",
+	"CK6Ba/wvLYqMJRSJGxRSTDLI//GnQkqvatv9v4RpNIr+b7ARzcC+VYNTO8vut3VWsyE+d4ONBBNtqSik",
+	"KEBqZombAU/BPHfHYFzDDGS0jiOWhp9b3lxtHzzGpacsg3FB9Tw4oCyQcemY6gPk6p6IyZ+QaFzlFWXZ",
+	"6iTRbAG/KQfQ5qlSumpt0F47jko/ffuQoW3fApfQ3utALoVW/iAWLLAyRZHZPTTkah8irIQ361Mp6Qp/",
+	"0xQBviFlIkQGlOOrGZ6p/xaWBYEtupggFiAXDJZhuAilQXajRUIGVMHYSK8nXnDWAngJYXqUlkDzccb4",
+	"ZXBLzXQWxrbO08m465jm5UJoGNMFSDprkjvNBNUbUnmZT7anJaLkOrz24ToTR4eTspuKTtCe0lkAuBWW",
+	"eoHKLBQCVeEWD4iRfYEOOiV8KpmENBr94bZ3C7lZHwNHOaV6/p5q4MmqfZjieDjO60Ziw7Ti5+PuVz93",
+	"vurG+6cSlO5tkLwLaDm7szcvyY8/DX8kzrWQFDRlmYpJkjHkPVFzUWYpmUjKkzkRnCQiRRY1j24ejq4i",
+	"4GVu+MkXNGPp2BEaxdUTxosSf5eclnouJPsCaRQj7iYsTYFHccSFHk9FyfG5pBrGGcuZNsMyMWN8nInk",
+	"svZTAp7TPEAuSE6zmvA2XLNnCzKUcaUpT8IKrTTVperQ524zIGkCTTtQe2kehEx+HZXmrd+joiO23A7B",
+	"84xqXKeFzBwVp9MmyWrWPu0PYevMivhFmVyCbm8NUgrZwbwdWrEL4ciRHK4TF5yBdzVNYjOGuAr6v90s",
+	"1PBZH+gm/oq1xiikg4bQMc9BKSZ4+5yJhIP3TkopgXdEByksWIf2dGgAK4KPM6r0WAHw64j33CjKy4wq",
+	"deYigub5pXt6IOysAo4TXLhnwIZh51lt0S06dm53kLSRPEhKyfTqHN2l3eAFVSw5Ka0bMW7UCA6fblg5",
+	"17rA/V4AlSD96In59caL4JffL/wFxyxh3m6vsTamdBq4Vb1kHHJK3lINS7oiJ6fvKsNWvTzHCDAB93IB",
+	"0oI3eno0PBoigaIATgsWjaJnR8OjZ5H1kOagAxsCDxRQmcztfcVgFdltbk/v0mgUvQVtgl91bsfFjdvr",
+	"H1fBq10hRV7oqG6btSyhft3L6ef3wGfIuafDYRzljPvfT9qQ/bh173s6HO649bVve9cJ8tuXwPdMmRu5",
+	"cbnE8hHnPRs+b8vx30KTDyJlUwYpDjreSfqNX1jfOf9OajfXMs+pXGFuwcjUnQBv7EZ+OMaj44ql6/3Y",
+	"eJd24MLEYxUsWLoTEi2Vva7Ue0TGbYaZE/morrdUnw+f7yDtxqWK278xAd8DQ9Rb0BZOiKZ3rxyW0pzx",
+	"AfU5JMQWW8APVYbAgau5za88WxG6oCyjkwyIFsQsw5SWFDF3FMUBOOKQKllVz2O08Bk6/WbIoJmVW8f9",
+	"J1yI6G7sVStX08N0mTnECoCUftbz4bBrs+oYAyduHP3koNHPDhh9fAAlNQduJFr3xn98XH+s4zJ07hA0",
+	"N8H3bYHytd3hG8TjdhDZA47ncyoBXamP60gBktiokdio8buAZx8+hOCabTIrt4VXn7z5BgFbz031Cfvs",
+	"UBRNAlyzDKyYpCg1fB843cOCEETxEqpAVjWR20HpxWaXuwVqvH0Ym9YhmBAlVBFK0tISHhM4mh2R45wI",
+	"SZ7MO6piEzM9CsTFm0tzkH8mwbyxH1oQPWfKSSa8l4vOu3e6Ey1spsN66KGboGJivTWhPCXOEhKsihDE",
+	"3PehkH15EdRMUdx+DH4hivsIwONwYsLkxaOtPATLyzwaPXFZCPcrvvnraC9taKS/eiiDYS5ZMj03Bfhc",
+	"qI0R+D5UYDcHEPim6IF7F0IF0hinQun3Zsg1BdyveC1hKkHNx1pcAq8NqaXAO94EcpjtiLpMElBqWmbE",
+	"Hnsj1btKEPxWL1Q9jPxEA0ubDG8rvEGOGRMqJpoyTn75/YJYaXggiVLvRZIodQeUOmUlYcaUBvkoroPC",
+	"UcNrgl1NhCa2xG4EZYtdKWSgIVDDhYW4BEVgAXJFlK35GLEjvWmJUe2EJpczaRK8IKkq/fUMy2AqJrYC",
+	"aN0tWh23O2FaQTY9+i9vecZXhpgP0EbG02sYGaUhXBY6rBTSzhMhtcgVz5H0EZkH5ZuMtCtYiClx1UCT",
+	"fLLeimYZXp6UwD1SqqnH7gA+F0LqXXn3D/DajulVj3FlwXrc4/sODLfi6AsrAh0A1w55WkVEVJr+NwMz",
+	"PtS+4vz7OBNby7XKie2ZRoP702DGh1baoWH2Ze8utXXcYOMXW+kNtEZOGKdGroG+yK0qhkzmmOo0QAta",
+	"LcdCMmdKC7l6VO9D1NsqX1N7t5XcaTPWmdSgEEWZUblTpc3IUzewX53VNV9tbjO77i8ddyLTunVPV6K9",
+	"FTrTBBcQHj5Hhpto3zGXWF5/lZXYt9A+Rg0++4v0Fj3ffpG+o6NxX5H+K4aGK9LbEzSL9A4dWgJPXUNa",
+	"MI/zH2v+bYcuEQXwljMwHsIlEbVYUpkqE9iqREiISS4kEAkJGjdTRWN6RZbAZnPGZ/atnlNORJaCJIJD",
+	"KASuYHrhCe4F1CXjqVg2TJT/smBk2tHjKprBryKi2D1cAlyGgpqvNjfUG/uevw40sTWTrvo5ZVLpAxob",
+	"hreY42nYP4dwj2aEukNcJhKaVbhrYH9fg4oF3LfUoGJePDaoXMPRGujUG1RqWBpYI7k7x+NBdW7H3g20",
+	"nocSGYmQaUPAt62odks09tr6B+dTpoQ6vi6pMi4GUstcwdJkcFVIsWApyPWgCvo73dUZ6FJyRfwc4qcY",
+	"KZDfzt4fkd/nwElCswxSd5smtsPS5utiQxx8dkhgKXCNLospgh+oQGoLZFBF7P6mTqcapFkYM0BH5Bw0",
+	"ukjyL60LUw7B44yVphpIIsQlA8cLY7rmYLOuhGoNeaGrMtxEiqUC+U+7p1sdqZEwLZU7A+aymO7wnL+y",
+	"NDl1DDmpWNgHep6NfQB4Szf/hgDHpcz+Ynb7ZBsIyGAFLgnnmEy0uAeL52WDQuVCk0TwKZuV8gEGlOaL",
+	"0l8L4O9ekZeCc0i0g63RpEprKuAE9dijeJf3rYP2pR9/a5jtCOzc9zcHzzNKfhMEmNJsdHfa9nAqT89s",
+	"cnv3wJhISJlEFGqByXw8tLPqhiBFGCdTSWc5MuPuE1W2LIR+zX84FRPrAZgiJb/kYsmxqQNHMKVK610o",
+	"F3oO0lulh2YFtvS/EgHwtBDMF1I2XzptiimhuoZL1fa6z1UfBt1ErL0VORgyiKX1PkoWJxu/b4lw12wb",
+	"JKBfWIlSqpp/Ch4AkYSDpw8ohD6w9GGDQekaQbtj6dtHTodVln7j7qVaX/fVMgK1hMCw/dnfIWhVdHE/",
+	"WG0EU8ZJkb/hLnSSrazlJe4jVKoh/ftXhkPsQfaXEmfMrP/bfbc7c4OqItMLka6uU/Tadrq7k6jN71mb",
+	"kz8GvXATvutvIJK4sE5fCvSx96EZDgNeB5RXg5jA5wJ57b09zSTQdIX1ngcX5r/+nMwpnwGRjdNMhSSU",
+	"cFi63wVl0quH60HZox9u1E0pSEGVWgqZ7tWNZlX1EDWq5sWb3a6hTL26eB4SFLzMjNgbjTIu8T+48q51",
+	"3SPWs3NMEqxnYvU2Qz5DzcMK+SxJfUM+O/obCPmoP7m1MbkvV3RlB+4bSDfdxx7uFAm5F1ttq0T9mME/",
+	"MIMvKw42gLbLa90l1G7CLe74yxr+b2fUyvNPhsPhMN7x1zTqpfzj4/gg92lJ8Yu5/W/Mfzr7d2+3oI35",
+	"ruKlgPnGiM/mczCXbW9LX5mVfmn+hgkexP4llQ57jWGB64lV+4OBcz+yV6jkBuO+4vKxp/TQb32QaaZt",
+	"1Mun3XPW7W33SOqGvwW2m/XxhvYL9upIj5g4qAOebRo7ukFRV+nBlftfv2Dfo8b929N3bra4iaJj0Ig8",
+	"IBsSDOs9mV9zXO8sjuAVuMLYwv/tb8UxH2o90E6c22tUv8l28/4d5e07R6sycsilo/3N4YPEdbOd1v7F",
+	"XESsQyoOAbnwsDNtCObvcI0GA9NiNhdKj34a/jQc0IINFk+j9cf1/wYA9GGDwr1ZAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -1,8 +1,6 @@
 package controllers
 
 import (
-	"net/http"
-
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
 	"github.com/gin-gonic/gin"
@@ -25,7 +23,12 @@ func (a Actors) GetActorsSearch(c *gin.Context, params api.GetActorsSearchParams
 		return
 	}
 
-	c.JSON(http.StatusOK, actors)
+	v := newValidators("actors")
+	for _, actor := range actors {
+		v.actor(actor)
+	}
+
+	sendConditional(c, v, actors)
 }
 
 func (a Actors) GetActorsId(c *gin.Context, id int) {
@@ -35,5 +38,5 @@ func (a Actors) GetActorsId(c *gin.Context, id int) {
 		return
 	}
 
-	c.JSON(http.StatusOK, actor)
+	sendConditional(c, newValidators("actor").actor(actor), actor)
 }
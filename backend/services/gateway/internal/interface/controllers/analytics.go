@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAnalyticsRange = 24 * time.Hour
+	defaultTopUsers       = 10
+	maxTimeSeriesBuckets  = 1000
+)
+
+type Analytics struct {
+	analytics repositories.RequestLogAnalytics
+}
+
+func NewAnalytics(analytics repositories.RequestLogAnalytics) Analytics {
+	return Analytics{
+		analytics: analytics,
+	}
+}
+
+func (a Analytics) GetAdminAnalyticsLatency(c *gin.Context, params api.GetAdminAnalyticsLatencyParams) {
+	from, to, ok := analyticsRange(c, params.From, params.To)
+	if !ok {
+		return
+	}
+
+	latencies, err := a.analytics.Latency(c.Request.Context(), from, to)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, latencies)
+}
+
+func (a Analytics) GetAdminAnalyticsErrors(c *gin.Context, params api.GetAdminAnalyticsErrorsParams) {
+	from, to, ok := analyticsRange(c, params.From, params.To)
+	if !ok {
+		return
+	}
+
+	rates, err := a.analytics.ErrorRates(c.Request.Context(), from, to)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (a Analytics) GetAdminAnalyticsTopUsers(c *gin.Context, params api.GetAdminAnalyticsTopUsersParams) {
+	from, to, ok := analyticsRange(c, params.From, params.To)
+	if !ok {
+		return
+	}
+
+	limit := defaultTopUsers
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	users, err := a.analytics.TopUsers(c.Request.Context(), from, to, limit)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (a Analytics) GetAdminAnalyticsActiveUsers(c *gin.Context, params api.GetAdminAnalyticsActiveUsersParams) {
+	from, to, ok := analyticsRange(c, params.From, params.To)
+	if !ok {
+		return
+	}
+
+	days, err := a.analytics.DailyActiveUsers(c.Request.Context(), from, to)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, days)
+}
+
+func (a Analytics) GetAdminAnalyticsTimeseries(c *gin.Context, params api.GetAdminAnalyticsTimeseriesParams) {
+	from, to, ok := analyticsRange(c, params.From, params.To)
+	if !ok {
+		return
+	}
+
+	bucket := time.Hour
+	if params.Bucket != nil {
+		parsed, err := time.ParseDuration(*params.Bucket)
+		if err != nil || parsed < time.Second {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "bucket must be a duration of at least 1s")
+			return
+		}
+		bucket = parsed
+	}
+
+	if to.Sub(from)/bucket > maxTimeSeriesBuckets {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "too many buckets, use a larger bucket or a shorter range")
+		return
+	}
+
+	path := ""
+	if params.Path != nil {
+		path = *params.Path
+	}
+
+	buckets, err := a.analytics.TimeSeries(c.Request.Context(), from, to, bucket, path)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, buckets)
+}
+
+// analyticsRange defaults to the last day and writes a 400 itself when the
+// range is empty.
+func analyticsRange(c *gin.Context, from, to *time.Time) (time.Time, time.Time, bool) {
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+
+	start := end.Add(-defaultAnalyticsRange)
+	if from != nil {
+		start = *from
+	}
+
+	if !start.Before(end) {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "from must be before to")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, end, true
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,24 +23,43 @@ func NewAuth(auth clients.Auth) Auth {
 }
 
 func (a Auth) PostLogin(c *gin.Context) {
-	id, token, err := a.auth.Login(c.Request.Context(), c.Request.Header.Get("Authorization"))
+	id, tokens, err := a.auth.Login(c.Request.Context(), c.Request.Header.Get("Authorization"), clientInfo(c))
 	if err != nil {
 		sendError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"id":    id,
-		"token": token,
+		"id":            id,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+func (a Auth) PostRefresh(c *gin.Context) {
+	body := &api.PostRefreshJSONRequestBody{}
+	if err := c.ShouldBindJSON(body); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid body in request")
+		return
+	}
+
+	id, tokens, err := a.auth.Refresh(c.Request.Context(), body.RefreshToken, clientInfo(c))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":            id,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
 	})
 }
 
 func (a Auth) PostRegister(c *gin.Context) {
 	raw, err := io.ReadAll(c.Request.Body)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "unable to get body",
-		})
+		problem.Abort(c, http.StatusInternalServerError, problem.CodeInternal, "unable to get body")
 		return
 	}
 
@@ -52,16 +74,21 @@ func (a Auth) PostRegister(c *gin.Context) {
 func (a Auth) PostLogout(c *gin.Context) {
 	header := c.Request.Header.Get("Authorization")
 	if !strings.HasPrefix(header, "Bearer ") {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid authorization header",
-		})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid authorization header")
 		return
 	}
 
-	if err := a.auth.Logout(c.Request.Context(), header[8:]); err != nil {
+	if err := a.auth.Logout(c.Request.Context(), header[7:]); err != nil {
 		sendError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+func clientInfo(c *gin.Context) entities.ClientInfo {
+	return entities.ClientInfo{
+		Device: c.Request.UserAgent(),
+		IP:     c.ClientIP(),
+	}
+}
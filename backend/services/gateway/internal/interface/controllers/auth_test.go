@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/clients/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// memoryRevocations has been tracking revocations for long enough for the
+// gateway to trust it.
+type memoryRevocations struct {
+	mu      sync.Mutex
+	revoked map[string]bool
+}
+
+func (m *memoryRevocations) Revoke(_ context.Context, token string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revoked[token] = true
+	return nil
+}
+
+func (m *memoryRevocations) IsRevoked(_ context.Context, token string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.revoked[token], nil
+}
+
+func (m *memoryRevocations) TrackedSince(context.Context) (time.Time, error) {
+	return time.Now().Add(-24 * time.Hour), nil
+}
+
+// fakeAuthService publishes one signing key, accepts the logout of the token
+// it issued only and would still authorize it, so a rejection after logout
+// has to come from the gateway itself.
+func fakeAuthService(t *testing.T) (host, token string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":      "7",
+		"username": "alice",
+		"sid":      "session",
+		"exp":      time.Now().Add(15 * time.Minute).Unix(),
+	})
+	signed.Header["kid"] = "current"
+	token, err = signed.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": "current",
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /logout", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != token {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	mux.HandleFunc("GET /authorize", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"user_id":7,"username":"alice","session_id":"session"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://"), token
+}
+
+func TestPostLogoutRevokesToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	host, token := fakeAuthService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	client := auth.NewJWTClient(ctx, auth.NewHTTPClient(host, time.Second), &memoryRevocations{revoked: map[string]bool{}}, auth.Config{
+		Host:                host,
+		Timeout:             time.Second,
+		JWKSRefreshInterval: time.Hour,
+		AuthorizeCacheTTL:   time.Minute,
+		AccessTokenTTL:      15 * time.Minute,
+	})
+
+	if _, err := client.Authorize(ctx, token); err != nil {
+		t.Fatalf("token rejected before logout: %s", err.Error())
+	}
+
+	router := gin.New()
+	router.POST("/api/logout", NewAuth(client).PostLogout)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("logout responded with %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := client.Authorize(ctx, token); !errors.Is(err, clients.ErrUnauthorized) {
+		t.Errorf("got %v after logout, want %v", err, clients.ErrUnauthorized)
+	}
+}
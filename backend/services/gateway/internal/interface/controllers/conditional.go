@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// validators builds an ETag and Last-Modified for a response from the
+// versions of the entities it is made of, so they can be computed without
+// encoding the body.
+type validators struct {
+	hash         hash.Hash
+	lastModified time.Time
+}
+
+func newValidators(kind string) *validators {
+	v := &validators{hash: sha256.New()}
+	v.hash.Write([]byte(kind))
+	return v
+}
+
+func (v *validators) add(parts ...any) *validators {
+	for _, part := range parts {
+		fmt.Fprintf(v.hash, "|%v", part)
+	}
+	return v
+}
+
+func (v *validators) touch(updatedAt time.Time) *validators {
+	v.add(updatedAt.UnixNano())
+	if updatedAt.After(v.lastModified) {
+		v.lastModified = updatedAt
+	}
+	return v
+}
+
+func (v *validators) movie(movie entities.Movie) *validators {
+	v.add("movie", movie.ID, movie.TheMovieDBID).touch(movie.UpdatedAt)
+	for _, genre := range movie.Genres {
+		v.add("genre", genre.ID, genre.Name)
+	}
+	for _, actor := range movie.Actors {
+		v.actor(actor)
+	}
+	return v
+}
+
+func (v *validators) actor(actor entities.Actor) *validators {
+	return v.add("actor", actor.ID).touch(actor.UpdatedAt)
+}
+
+func (v *validators) review(review entities.Review) *validators {
+	return v.add("review", review.UserID, review.MovieID).touch(review.UpdatedAt)
+}
+
+func (v *validators) rating(rating entities.Rating) *validators {
+	return v.add("rating", rating.UserID, rating.MovieID, rating.Rating)
+}
+
+func (v *validators) etag() string {
+	return `"` + hex.EncodeToString(v.hash.Sum(nil)[:16]) + `"`
+}
+
+// sendConditional answers with 304 when the client already holds the current
+// representation and with the JSON body otherwise.
+func sendConditional(c *gin.Context, v *validators, body any) {
+	etag := v.etag()
+	c.Header("ETag", etag)
+	if !v.lastModified.IsZero() {
+		c.Header("Last-Modified", v.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, v.lastModified) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// notModified follows RFC 9110: If-None-Match takes precedence and uses weak
+// comparison, If-Modified-Since is only looked at when it is absent.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
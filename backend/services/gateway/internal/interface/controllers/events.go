@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+// recordEvent fills in when, who and the trace of the event. Analytics never
+// fail the request, errors are only logged.
+func recordEvent(c *gin.Context, events repositories.Events, event entities.Event) {
+	ctx := c.Request.Context()
+	user, _ := middleware.UserFromContext(ctx)
+
+	event.Timestamp = time.Now()
+	event.UserID = user.ID
+	event.TraceID = tracing.GetTraceID(ctx)
+
+	if err := events.Record(ctx, event); err != nil {
+		ctxlogrus.Extract(ctx).Warnf("unable to record %s event: %s", event.Type, err.Error())
+	}
+}
+
+// recordActivity feeds the trending scores, like events it never fails the
+// request.
+func recordActivity(c *gin.Context, trending repositories.Trending, movieID int, weight float64) {
+	if err := trending.Record(c.Request.Context(), movieID, weight); err != nil {
+		ctxlogrus.Extract(c.Request.Context()).Warnf("unable to record trending activity: %s", err.Error())
+	}
+}
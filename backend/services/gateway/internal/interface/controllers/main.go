@@ -4,10 +4,14 @@ import "github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/inte
 
 type Main struct {
 	Actors
+	Analytics
 	Auth
+	Me
 	Movies
+	OIDC
 	Ratings
 	Reviews
+	Sessions
 	Users
 }
 
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type userExport struct {
+	UserID      int                   `json:"user_id"`
+	Username    string                `json:"username"`
+	Reviews     []entities.Review     `json:"reviews"`
+	Ratings     []entities.Rating     `json:"ratings"`
+	RequestLogs []entities.RequestLog `json:"request_logs"`
+}
+
+type Me struct {
+	reviews     repositories.Reviews
+	ratings     repositories.Ratings
+	requestLogs repositories.RequestLogs
+	deletions   repositories.AccountDeletions
+
+	auth clients.Auth
+}
+
+func NewMe(reviews repositories.Reviews, ratings repositories.Ratings, requestLogs repositories.RequestLogs, deletions repositories.AccountDeletions, auth clients.Auth) Me {
+	return Me{
+		reviews:     reviews,
+		ratings:     ratings,
+		requestLogs: requestLogs,
+		deletions:   deletions,
+
+		auth: auth,
+	}
+}
+
+func (m Me) GetMeExport(c *gin.Context, params api.GetMeExportParams) {
+	user, err := middleware.UserFromContext(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	export := userExport{
+		UserID:   user.ID,
+		Username: user.Username,
+	}
+
+	if export.Reviews, err = m.reviews.GetByUserID(c.Request.Context(), user.ID); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if export.Ratings, err = m.ratings.GetUserRatings(c.Request.Context(), user.ID); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if export.RequestLogs, err = m.requestLogs.GetByUserID(c.Request.Context(), user.ID); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if params.Format == nil || *params.Format != api.Zip {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d.json"`, user.ID))
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	// Build the archive before the status goes out, so a failure can still be
+	// answered with an error instead of a truncated 200.
+	var archive bytes.Buffer
+	if err := writeExportZip(&archive, export); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d.zip"`, user.ID))
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+func (m Me) DeleteMe(c *gin.Context) {
+	user, err := middleware.UserFromContext(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	deletion, err := m.deletions.Create(c.Request.Context(), entities.AccountDeletion{
+		UserID: user.ID,
+		Step:   entities.AccountDeletionPending,
+	})
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	// Sign the user out everywhere right away, the data itself is erased by
+	// the background worker.
+	if err = m.auth.RevokeSessions(c.Request.Context(), user.ID); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deletion)
+}
+
+func writeExportZip(w io.Writer, export userExport) error {
+	archive := zip.NewWriter(w)
+
+	files := map[string]any{
+		"profile.json": gin.H{
+			"user_id":  export.UserID,
+			"username": export.Username,
+		},
+		"reviews.json":      export.Reviews,
+		"ratings.json":      export.Ratings,
+		"request_logs.json": export.RequestLogs,
+	}
+
+	for name, content := range files {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
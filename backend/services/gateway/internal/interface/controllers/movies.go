@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -22,13 +23,19 @@ func AddStreamLink(movie entities.Movie) MovieWithStreamLink {
 	}
 }
 
+const defaultTrendingLimit = 20
+
 type Movies struct {
-	movies repositories.Movies
+	movies   repositories.Movies
+	events   repositories.Events
+	trending repositories.Trending
 }
 
-func NewMovies(movies repositories.Movies) Movies {
+func NewMovies(movies repositories.Movies, events repositories.Events, trending repositories.Trending) Movies {
 	return Movies{
-		movies: movies,
+		movies:   movies,
+		events:   events,
+		trending: trending,
 	}
 }
 
@@ -39,10 +46,113 @@ func (m Movies) GetMoviesId(c *gin.Context, id int) {
 		return
 	}
 
-	c.JSON(http.StatusOK, AddStreamLink(movie))
+	recordEvent(c, m.events, entities.Event{Type: entities.EventMovieViewed, MovieID: movie.ID})
+	recordActivity(c, m.trending, movie.ID, entities.ActivityView)
+
+	sendConditional(c, newValidators("movie").movie(movie), AddStreamLink(movie))
+}
+
+func (m Movies) PostMoviesIdStream(c *gin.Context, id int) {
+	if _, err := m.movies.GetByID(c.Request.Context(), id); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	recordEvent(c, m.events, entities.Event{Type: entities.EventStreamLinkOpened, MovieID: id})
+	recordActivity(c, m.trending, id, entities.ActivityStream)
+
+	c.Status(http.StatusNoContent)
+}
+
+func (m Movies) GetMoviesTrending(c *gin.Context, params api.GetMoviesTrendingParams) {
+	window := entities.TrendingDay
+	if params.Window != nil {
+		window = entities.TrendingWindow(*params.Window)
+	}
+
+	limit := defaultTrendingLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	scores, err := m.trending.Top(c.Request.Context(), window, limit)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	movies, err := m.trendingMovies(c.Request.Context(), scores)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	v := newValidators("movies")
+	trending := make([]MovieWithStreamLink, 0, len(movies))
+	for _, movie := range movies {
+		v.movie(movie)
+		trending = append(trending, AddStreamLink(movie))
+	}
+
+	sendConditional(c, v, trending)
+}
+
+// trendingMovies loads the movies of scores in a fixed number of queries and
+// returns them in the order of the scores. Movies deleted since they were
+// scored are left out.
+func (m Movies) trendingMovies(ctx context.Context, scores []entities.MovieScore) ([]entities.Movie, error) {
+	ids := make([]int, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.MovieID)
+	}
+
+	movies, err := m.movies.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, err := m.movies.GetGenresByMovieIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	actors, err := m.movies.GetActorsByMovieIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty lists stay lists in the response, as they are when preloaded.
+	byID := make(map[int]entities.Movie, len(movies))
+	for _, movie := range movies {
+		movie.Genres = append([]entities.Genre{}, genres[movie.ID]...)
+		movie.Actors = append([]entities.Actor{}, actors[movie.ID]...)
+		byID[movie.ID] = movie
+	}
+
+	ordered := make([]entities.Movie, 0, len(movies))
+	for _, id := range ids {
+		if movie, ok := byID[id]; ok {
+			ordered = append(ordered, movie)
+		}
+	}
+
+	return ordered, nil
 }
 
 func (m Movies) GetMoviesPopular(c *gin.Context, params api.GetMoviesPopularParams) {
+	if page, v, ok := m.popular(c, params); ok {
+		sendConditional(c, v, page.Items)
+	}
+}
+
+// MoviePage is a page of /movies/popular as /api/v2 returns it.
+type MoviePage struct {
+	Items []MovieWithStreamLink `json:"items"`
+	Page  int                   `json:"page"`
+	Size  int                   `json:"size"`
+}
+
+func (m Movies) popular(c *gin.Context, params api.GetMoviesPopularParams) (MoviePage, *validators, bool) {
 	pageNumber := func() int {
 		if params.Page == nil {
 			return 1
@@ -59,25 +169,20 @@ func (m Movies) GetMoviesPopular(c *gin.Context, params api.GetMoviesPopularPara
 		return *params.Size
 	}()
 
-	if pageNumber <= 0 || pageSize <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid pagination",
-		})
-		return
-	}
-
 	found, err := m.movies.GetPopular(c.Request.Context(), (pageNumber-1)*pageSize, pageSize)
 	if err != nil {
 		sendError(c, err)
-		return
+		return MoviePage{}, nil, false
 	}
 
+	v := newValidators("movies")
 	withStreamLinks := make([]MovieWithStreamLink, 0, len(found))
 	for _, movie := range found {
+		v.movie(movie)
 		withStreamLinks = append(withStreamLinks, AddStreamLink(movie))
 	}
 
-	c.JSON(http.StatusOK, withStreamLinks)
+	return MoviePage{Items: withStreamLinks, Page: pageNumber, Size: pageSize}, v, true
 }
 
 func (m Movies) GetMoviesSearch(c *gin.Context, params api.GetMoviesSearchParams) {
@@ -87,10 +192,14 @@ func (m Movies) GetMoviesSearch(c *gin.Context, params api.GetMoviesSearchParams
 		return
 	}
 
+	recordEvent(c, m.events, entities.Event{Type: entities.EventSearchPerformed, Query: params.Prompt, ResultCount: len(found)})
+
+	v := newValidators("movies")
 	withStreamLinks := make([]MovieWithStreamLink, 0, len(found))
 	for _, movie := range found {
+		v.movie(movie)
 		withStreamLinks = append(withStreamLinks, AddStreamLink(movie))
 	}
 
-	c.JSON(http.StatusOK, withStreamLinks)
+	sendConditional(c, v, withStreamLinks)
 }
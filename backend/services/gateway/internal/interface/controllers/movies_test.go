@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+)
+
+// moviesStub stores movies by id and counts the queries made against it.
+type moviesStub struct {
+	repositories.Movies
+
+	movies  map[int]entities.Movie
+	genres  map[int][]entities.Genre
+	queries int
+}
+
+func (s *moviesStub) GetByID(context.Context, int) (entities.Movie, error) {
+	panic("movies are loaded one by one")
+}
+
+func (s *moviesStub) GetByIDs(_ context.Context, ids []int) ([]entities.Movie, error) {
+	s.queries++
+
+	// Rows come back in storage order, not in the order asked for.
+	var movies []entities.Movie
+	for id := range 10 {
+		if movie, ok := s.movies[id]; ok && slices.Contains(ids, id) {
+			movies = append(movies, movie)
+		}
+	}
+	return movies, nil
+}
+
+func (s *moviesStub) GetGenresByMovieIDs(context.Context, []int) (map[int][]entities.Genre, error) {
+	s.queries++
+	return s.genres, nil
+}
+
+func (s *moviesStub) GetActorsByMovieIDs(context.Context, []int) (map[int][]entities.Actor, error) {
+	s.queries++
+	return nil, nil
+}
+
+func TestTrendingMovies(t *testing.T) {
+	movies := &moviesStub{
+		movies: map[int]entities.Movie{
+			1: {ID: 1, Title: "Alien"},
+			2: {ID: 2, Title: "Heat"},
+			3: {ID: 3, Title: "Ran"},
+		},
+		genres: map[int][]entities.Genre{2: {{ID: 5, Name: "Crime"}}},
+	}
+
+	// Movie 4 was deleted after it was scored.
+	scores := []entities.MovieScore{{MovieID: 3, Score: 9}, {MovieID: 4, Score: 7}, {MovieID: 2, Score: 5}, {MovieID: 1, Score: 1}}
+
+	got, err := NewMovies(movies, nil, nil).trendingMovies(context.Background(), scores)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int
+	for _, movie := range got {
+		ids = append(ids, movie.ID)
+		if movie.Genres == nil || movie.Actors == nil {
+			t.Errorf("movie %d: got nil genres or actors, want empty lists", movie.ID)
+		}
+	}
+	if want := []int{3, 2, 1}; !slices.Equal(ids, want) {
+		t.Errorf("got movies %v, want %v", ids, want)
+	}
+	if len(got) == 3 && (len(got[1].Genres) != 1 || got[1].Genres[0].Name != "Crime") {
+		t.Errorf("got genres %v for movie 2", got[1].Genres)
+	}
+	if movies.queries != 3 {
+		t.Errorf("got %d queries, want 3", movies.queries)
+	}
+}
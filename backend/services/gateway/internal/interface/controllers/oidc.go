@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+)
+
+type OIDC struct {
+	oidc   clients.OIDC
+	auth   clients.Auth
+	states repositories.LoginStates
+
+	stateTTL         time.Duration
+	frontendRedirect string
+}
+
+func NewOIDC(oidc clients.OIDC, auth clients.Auth, states repositories.LoginStates, stateTTL time.Duration, frontendRedirect string) OIDC {
+	return OIDC{
+		oidc:   oidc,
+		auth:   auth,
+		states: states,
+
+		stateTTL:         stateTTL,
+		frontendRedirect: frontendRedirect,
+	}
+}
+
+// stateCookie binds a login attempt to the browser that started it, so a
+// callback carrying someone else's state is refused.
+const stateCookie = "oidc_state"
+
+func (o OIDC) GetOidcProviderAuthorize(c *gin.Context, provider string) {
+	nonce, err := randomString()
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	state, err := randomString()
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	loginState := entities.LoginState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+	}
+
+	// The route is public, but a caller that is already signed in wants to
+	// link the external identity to the current account.
+	if header := c.Request.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		user, err := o.auth.Authorize(c.Request.Context(), header[7:])
+		if err != nil {
+			sendError(c, err)
+			return
+		}
+		loginState.LinkUserID = user.ID
+	}
+
+	authURL, err := o.oidc.AuthCodeURL(c.Request.Context(), provider, state, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if err = o.states.Save(c.Request.Context(), state, loginState, o.stateTTL); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     stateCookie,
+		Value:    hashState(state),
+		Path:     "/",
+		MaxAge:   int(o.stateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   isSecure(c.Request),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"authorization_url": authURL,
+	})
+}
+
+func (o OIDC) GetOidcProviderCallback(c *gin.Context, provider string, params api.GetOidcProviderCallbackParams) {
+	cookie, err := c.Request.Cookie(stateCookie)
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     stateCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecure(c.Request),
+		SameSite: http.SameSiteLaxMode,
+	})
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(hashState(params.State))) != 1 {
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized, "login was not started in this browser")
+		return
+	}
+
+	loginState, err := o.states.Pop(c.Request.Context(), params.State)
+	if err != nil || loginState.Provider != provider {
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized, "unknown or expired login state")
+		return
+	}
+
+	if params.Error != nil || params.Code == nil {
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeLoginRejected, "login was rejected by identity provider")
+		return
+	}
+
+	identity, err := o.oidc.Exchange(c.Request.Context(), provider, *params.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	id, tokens, err := o.auth.ExternalLogin(c.Request.Context(), identity, loginState.LinkUserID, clientInfo(c))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if o.frontendRedirect == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"id":            id,
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
+		})
+		return
+	}
+
+	fragment := url.Values{}
+	fragment.Set("id", strconv.Itoa(id))
+	fragment.Set("token", tokens.AccessToken)
+	fragment.Set("refresh_token", tokens.RefreshToken)
+
+	c.Redirect(http.StatusFound, o.frontendRedirect+"#"+fragment.Encode())
+}
+
+func randomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func isSecure(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
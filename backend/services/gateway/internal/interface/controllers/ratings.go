@@ -11,14 +11,18 @@ import (
 )
 
 type Ratings struct {
-	ratings repositories.Ratings
-	movies  repositories.Movies
+	ratings  repositories.Ratings
+	movies   repositories.Movies
+	events   repositories.Events
+	trending repositories.Trending
 }
 
-func NewRatings(ratings repositories.Ratings, movies repositories.Movies) Ratings {
+func NewRatings(ratings repositories.Ratings, movies repositories.Movies, events repositories.Events, trending repositories.Trending) Ratings {
 	return Ratings{
-		ratings: ratings,
-		movies:  movies,
+		ratings:  ratings,
+		movies:   movies,
+		events:   events,
+		trending: trending,
 	}
 }
 
@@ -29,13 +33,6 @@ func (r Ratings) PostRating(c *gin.Context, params api.PostRatingParams) {
 		return
 	}
 
-	if params.Rating < 0 || params.Rating > 10 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "rating must be between 0 and 10",
-		})
-		return
-	}
-
 	if err = r.ratings.CreateRating(c.Request.Context(), entities.Rating{
 		UserID:  user.ID,
 		MovieID: params.MovieId,
@@ -50,6 +47,9 @@ func (r Ratings) PostRating(c *gin.Context, params api.PostRatingParams) {
 		return
 	}
 
+	recordEvent(c, r.events, entities.Event{Type: entities.EventRatingSubmitted, MovieID: params.MovieId, Rating: params.Rating})
+	recordActivity(c, r.trending, params.MovieId, entities.ActivityRating)
+
 	c.JSON(http.StatusOK, gin.H{})
 }
 
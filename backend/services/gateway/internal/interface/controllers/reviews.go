@@ -1,24 +1,25 @@
 package controllers
 
 import (
-	"encoding/json"
-	"io"
 	"net/http"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
 	"github.com/gin-gonic/gin"
 )
 
 type Reviews struct {
-	reviews repositories.Reviews
+	reviews  repositories.Reviews
+	trending repositories.Trending
 }
 
-func NewReviews(reviews repositories.Reviews) Reviews {
+func NewReviews(reviews repositories.Reviews, trending repositories.Trending) Reviews {
 	return Reviews{
-		reviews: reviews,
+		reviews:  reviews,
+		trending: trending,
 	}
 }
 
@@ -44,7 +45,12 @@ func (r Reviews) GetReviewsMovieId(c *gin.Context, movieId int) {
 		return
 	}
 
-	c.JSON(http.StatusOK, reviews)
+	v := newValidators("reviews")
+	for _, review := range reviews {
+		v.review(review)
+	}
+
+	sendConditional(c, v, reviews)
 }
 
 func (r Reviews) PostReviewsMovieId(c *gin.Context, movieId int) {
@@ -54,33 +60,24 @@ func (r Reviews) PostReviewsMovieId(c *gin.Context, movieId int) {
 		return
 	}
 
-	raw, err := io.ReadAll(c.Request.Body)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "unable to get body",
-		})
-		return
-	}
-
 	body := &api.PostReviewsMovieIdJSONRequestBody{}
-
-	if err := json.Unmarshal(raw, body); err != nil || body.Liked == nil || body.Text == nil || body.Title == nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid body in request",
-		})
+	if err := c.ShouldBindJSON(body); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid body in request")
 		return
 	}
 
 	if err = r.reviews.CreateOrUpdateReview(c.Request.Context(), entities.Review{
 		UserID:  user.ID,
 		MovieID: movieId,
-		Liked:   *body.Liked,
-		Title:   *body.Title,
-		Text:    *body.Text,
+		Liked:   body.Liked,
+		Title:   body.Title,
+		Text:    body.Text,
 	}); err != nil {
 		sendError(c, err)
 		return
 	}
 
+	recordActivity(c, r.trending, movieId, entities.ActivityReview)
+
 	c.JSON(http.StatusOK, gin.H{})
 }
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type Sessions struct {
+	auth clients.Auth
+}
+
+func NewSessions(auth clients.Auth) Sessions {
+	return Sessions{
+		auth: auth,
+	}
+}
+
+func (s Sessions) GetSessions(c *gin.Context) {
+	user, err := middleware.UserFromContext(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	sessions, err := s.auth.Sessions(c.Request.Context(), user.ID)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == user.SessionID
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (s Sessions) DeleteSessions(c *gin.Context) {
+	user, err := middleware.UserFromContext(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if err = s.auth.RevokeSessions(c.Request.Context(), user.ID); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (s Sessions) DeleteSessionsSessionId(c *gin.Context, sessionId string) {
+	user, err := middleware.UserFromContext(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if err = s.auth.RevokeSession(c.Request.Context(), user.ID, sessionId); err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -1,8 +1,6 @@
 package controllers
 
 import (
-	"net/http"
-
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
 	"github.com/gin-gonic/gin"
@@ -37,14 +35,22 @@ func (u Users) GetUsersId(c *gin.Context, id int) {
 		return
 	}
 
-	username, err := u.auth.Username(c.Request.Context(), id)
+	user, err := u.auth.User(c.Request.Context(), id)
 	if err != nil {
 		sendError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"username": username,
+	v := newValidators("user").add(user.ID, user.Username).touch(user.UpdatedAt)
+	for _, review := range reviews {
+		v.review(review)
+	}
+	for _, rating := range ratings {
+		v.rating(rating)
+	}
+
+	sendConditional(c, v, gin.H{
+		"username": user.Username,
 		"ratings":  ratings,
 		"reviews":  reviews,
 	})
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/api"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/apiv2"
+	"github.com/gin-gonic/gin"
+)
+
+// V2 serves /api/v2 with the controllers of the first version. Operations
+// without parameters are promoted from Main as they are, the others convert
+// their parameters or, where the versions differ, shape the response.
+type V2 struct {
+	Main
+}
+
+var _ apiv2.ServerInterface = (*V2)(nil)
+
+func (v V2) GetActorsSearch(c *gin.Context, params apiv2.GetActorsSearchParams) {
+	v.Actors.GetActorsSearch(c, api.GetActorsSearchParams(params))
+}
+
+func (v V2) GetAdminAnalyticsActiveUsers(c *gin.Context, params apiv2.GetAdminAnalyticsActiveUsersParams) {
+	v.Analytics.GetAdminAnalyticsActiveUsers(c, api.GetAdminAnalyticsActiveUsersParams(params))
+}
+
+func (v V2) GetAdminAnalyticsErrors(c *gin.Context, params apiv2.GetAdminAnalyticsErrorsParams) {
+	v.Analytics.GetAdminAnalyticsErrors(c, api.GetAdminAnalyticsErrorsParams(params))
+}
+
+func (v V2) GetAdminAnalyticsLatency(c *gin.Context, params apiv2.GetAdminAnalyticsLatencyParams) {
+	v.Analytics.GetAdminAnalyticsLatency(c, api.GetAdminAnalyticsLatencyParams(params))
+}
+
+func (v V2) GetAdminAnalyticsTimeseries(c *gin.Context, params apiv2.GetAdminAnalyticsTimeseriesParams) {
+	v.Analytics.GetAdminAnalyticsTimeseries(c, api.GetAdminAnalyticsTimeseriesParams(params))
+}
+
+func (v V2) GetAdminAnalyticsTopUsers(c *gin.Context, params apiv2.GetAdminAnalyticsTopUsersParams) {
+	v.Analytics.GetAdminAnalyticsTopUsers(c, api.GetAdminAnalyticsTopUsersParams(params))
+}
+
+func (v V2) GetMeExport(c *gin.Context, params apiv2.GetMeExportParams) {
+	v.Me.GetMeExport(c, api.GetMeExportParams{
+		Format: (*api.GetMeExportParamsFormat)(params.Format),
+	})
+}
+
+func (v V2) GetMoviesPopular(c *gin.Context, params apiv2.GetMoviesPopularParams) {
+	if page, validators, ok := v.Movies.popular(c, api.GetMoviesPopularParams(params)); ok {
+		sendConditional(c, validators, page)
+	}
+}
+
+func (v V2) GetMoviesSearch(c *gin.Context, params apiv2.GetMoviesSearchParams) {
+	v.Movies.GetMoviesSearch(c, api.GetMoviesSearchParams(params))
+}
+
+func (v V2) GetMoviesTrending(c *gin.Context, params apiv2.GetMoviesTrendingParams) {
+	v.Movies.GetMoviesTrending(c, api.GetMoviesTrendingParams{
+		Window: (*api.GetMoviesTrendingParamsWindow)(params.Window),
+		Limit:  params.Limit,
+	})
+}
+
+func (v V2) GetOidcProviderCallback(c *gin.Context, provider string, params apiv2.GetOidcProviderCallbackParams) {
+	v.OIDC.GetOidcProviderCallback(c, provider, api.GetOidcProviderCallbackParams(params))
+}
+
+func (v V2) DeleteRating(c *gin.Context, params apiv2.DeleteRatingParams) {
+	v.Ratings.DeleteRating(c, api.DeleteRatingParams(params))
+}
+
+func (v V2) PostRating(c *gin.Context, params apiv2.PostRatingParams) {
+	v.Ratings.PostRating(c, api.PostRatingParams(params))
+}
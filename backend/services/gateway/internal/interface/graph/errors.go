@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// presentError gives resolver errors the same codes as the problem responses
+// of the REST API and keeps unexpected ones out of the response.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var gqlErr *gqlerror.Error
+	switch {
+	case errors.Is(err, repositories.ErrNotFound), errors.Is(err, clients.ErrNotFound):
+		presented.Message = "not found"
+		presented.Extensions = map[string]any{"code": problem.CodeNotFound}
+	case errors.As(err, &gqlErr) && gqlErr.Err == nil:
+		return presented
+	default:
+		ctxlogrus.Extract(ctx).Errorf("unable to resolve %s: %s", presented.Path, err.Error())
+		presented.Message = "internal error"
+		presented.Extensions = map[string]any{"code": problem.CodeInternal}
+	}
+
+	return presented
+}
+
+func invalidInput(message string) error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]any{"code": problem.CodeInvalidInput},
+	}
+}
@@ -0,0 +1,37 @@
+package graph
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const queryCacheSize = 1000
+
+// NewHandler serves the schema over GET and POST. Every request gets its own
+// loaders, and queries beyond the limits are rejected before anything is
+// resolved.
+func NewHandler(resolver *Resolver, limits Limits) http.Handler {
+	server := handler.New(NewExecutableSchema(Config{
+		Resolvers:  resolver,
+		Complexity: complexity(),
+	}))
+
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.SetQueryCache(lru.New[*ast.QueryDocument](queryCacheSize))
+
+	server.Use(extension.Introspection{})
+	server.Use(depthLimit(limits.MaxDepth))
+	server.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+
+	server.SetErrorPresenter(presentError)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.ServeHTTP(w, r.WithContext(withLoaders(r.Context(), resolver.newLoaders())))
+	})
+}
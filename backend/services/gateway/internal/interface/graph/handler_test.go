@@ -0,0 +1,303 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+)
+
+// calls counts the repository methods a query ended up calling.
+type calls struct {
+	mu sync.Mutex
+	n  map[string]int
+}
+
+func (c *calls) add(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.n == nil {
+		c.n = map[string]int{}
+	}
+	c.n[method]++
+}
+
+func (c *calls) get(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.n[method]
+}
+
+// The catalogue: movies 1 to 3, each played by actors 10 and 11 and reviewed
+// once.
+var catalogue = []int{1, 2, 3}
+
+type moviesStub struct {
+	repositories.Movies
+	calls *calls
+}
+
+func (m moviesStub) GetPopular(_ context.Context, offset, limit int) ([]entities.Movie, error) {
+	m.calls.add("GetPopular")
+
+	var movies []entities.Movie
+	for _, id := range catalogue[min(offset, len(catalogue)):min(offset+limit, len(catalogue))] {
+		movies = append(movies, entities.Movie{ID: id})
+	}
+	return movies, nil
+}
+
+func (m moviesStub) GetByIDs(_ context.Context, ids []int) ([]entities.Movie, error) {
+	m.calls.add("GetByIDs")
+
+	var movies []entities.Movie
+	for _, id := range ids {
+		if slices.Contains(catalogue, id) {
+			movies = append(movies, entities.Movie{ID: id})
+		}
+	}
+	return movies, nil
+}
+
+func (m moviesStub) GetGenresByMovieIDs(_ context.Context, _ []int) (map[int][]entities.Genre, error) {
+	m.calls.add("GetGenresByMovieIDs")
+	return map[int][]entities.Genre{}, nil
+}
+
+func (m moviesStub) GetActorsByMovieIDs(_ context.Context, movieIDs []int) (map[int][]entities.Actor, error) {
+	m.calls.add("GetActorsByMovieIDs")
+
+	actors := make(map[int][]entities.Actor, len(movieIDs))
+	for _, id := range movieIDs {
+		actors[id] = []entities.Actor{{ID: 10}, {ID: 11}}
+	}
+	return actors, nil
+}
+
+func (m moviesStub) GetIDsByActorIDs(_ context.Context, actorIDs []int) (map[int][]int, error) {
+	m.calls.add("GetIDsByActorIDs")
+
+	ids := make(map[int][]int, len(actorIDs))
+	for _, id := range actorIDs {
+		ids[id] = catalogue
+	}
+	return ids, nil
+}
+
+type reviewsStub struct {
+	repositories.Reviews
+	calls *calls
+}
+
+func (r reviewsStub) GetByMovieIDs(_ context.Context, movieIDs []int) (map[int][]entities.Review, error) {
+	r.calls.add("GetReviewsByMovieIDs")
+
+	reviews := make(map[int][]entities.Review, len(movieIDs))
+	for _, id := range movieIDs {
+		reviews[id] = []entities.Review{{MovieID: id, UserID: 100, Title: "review"}}
+	}
+	return reviews, nil
+}
+
+func (r reviewsStub) GetByUserIDs(_ context.Context, _ []int) (map[int][]entities.Review, error) {
+	r.calls.add("GetReviewsByUserIDs")
+	return map[int][]entities.Review{}, nil
+}
+
+// ratingsStub has no ratings, the loaders only need its methods to exist.
+type ratingsStub struct {
+	repositories.Ratings
+	calls *calls
+}
+
+func (r ratingsStub) GetByMovieIDs(_ context.Context, _ []int) (map[int][]entities.Rating, error) {
+	r.calls.add("GetRatingsByMovieIDs")
+	return map[int][]entities.Rating{}, nil
+}
+
+func (r ratingsStub) GetByUserIDs(_ context.Context, _ []int) (map[int][]entities.Rating, error) {
+	r.calls.add("GetRatingsByUserIDs")
+	return map[int][]entities.Rating{}, nil
+}
+
+func newTestHandler(t *testing.T, limits Limits) (http.Handler, *calls) {
+	t.Helper()
+
+	c := &calls{}
+	resolver := NewResolver(
+		moviesStub{calls: c},
+		repositories.Actors(nil),
+		reviewsStub{calls: c},
+		ratingsStub{calls: c},
+		clients.Auth(nil),
+	)
+
+	return NewHandler(resolver, limits), c
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, handler http.Handler, q string) response {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"query": q})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unable to decode %q: %s", rec.Body.String(), err.Error())
+	}
+	return resp
+}
+
+func errorCode(resp response) string {
+	if len(resp.Errors) == 0 {
+		return ""
+	}
+
+	code, _ := resp.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxDepth: 3, MaxComplexity: 100}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+	}{
+		{
+			name:  "within limits",
+			query: `{ popularMovies(size: 3) { id actors { id } } }`,
+		},
+		{
+			name:     "too deep",
+			query:    `{ popularMovies(size: 1) { actors { movies { id } } } }`,
+			wantCode: errDepthLimit,
+		},
+		{
+			name: "too deep through a fragment",
+			query: `{ popularMovies(size: 1) { ...cast } }
+				fragment cast on Movie { actors { movies { id } } }`,
+			wantCode: errDepthLimit,
+		},
+		{
+			name:     "too deep through an inline fragment",
+			query:    `{ popularMovies(size: 1) { ... on Movie { actors { movies { id } } } } }`,
+			wantCode: errDepthLimit,
+		},
+		{
+			name:  "fragment within the depth",
+			query: `{ popularMovies(size: 1) { ...cast } } fragment cast on Movie { actors { id } }`,
+		},
+		{
+			name:  "introspection is not counted",
+			query: `{ __schema { types { fields { type { ofType { name } } } } } }`,
+		},
+		{
+			// 1 + 20 * (1 + 10 * 1)
+			name:     "too complex",
+			query:    `{ popularMovies(size: 20) { actors { id } } }`,
+			wantCode: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+		{
+			name:     "too complex through search",
+			query:    `{ searchActors(prompt: "a") { movies { id title } } }`,
+			wantCode: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, c := newTestHandler(t, limits)
+
+			resp := query(t, handler, tt.query)
+			if code := errorCode(resp); code != tt.wantCode {
+				t.Fatalf("got code %q, want %q: %+v", code, tt.wantCode, resp.Errors)
+			}
+
+			if tt.wantCode != "" && c.get("GetPopular") != 0 {
+				t.Error("a rejected query reached the repositories")
+			}
+		})
+	}
+}
+
+func TestNestedListsBatchPerLevel(t *testing.T) {
+	handler, c := newTestHandler(t, Limits{MaxDepth: 10, MaxComplexity: 100_000})
+
+	resp := query(t, handler, `{
+		popularMovies(size: 3) {
+			actors {
+				movies {
+					reviews { title }
+				}
+			}
+		}
+	}`)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("got errors: %+v", resp.Errors)
+	}
+
+	var data struct {
+		PopularMovies []struct {
+			Actors []struct {
+				Movies []struct {
+					Reviews []struct{ Title string }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews := 0
+	for _, movie := range data.PopularMovies {
+		for _, actor := range movie.Actors {
+			for _, played := range actor.Movies {
+				reviews += len(played.Reviews)
+			}
+		}
+	}
+	// 3 movies, 2 actors each, who each played in the same 3 movies.
+	if reviews != 3*2*3 {
+		t.Errorf("got %d reviews, want %d", reviews, 3*2*3)
+	}
+
+	for _, method := range []string{
+		"GetPopular",
+		"GetActorsByMovieIDs",
+		"GetIDsByActorIDs",
+		"GetByIDs",
+		"GetReviewsByMovieIDs",
+	} {
+		if got := c.get(method); got != 1 {
+			t.Errorf("%s called %d times, want once", method, got)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type Limits struct {
+	// MaxDepth bounds how deeply selections nest, introspection fields are
+	// not counted.
+	MaxDepth int
+	// MaxComplexity bounds the estimated cost of a query, a list field costs
+	// its selection once per element it is expected to return.
+	MaxComplexity int
+}
+
+// listComplexity is how many elements a list without a page size is expected
+// to return.
+const listComplexity = 10
+
+func complexity() ComplexityRoot {
+	list := func(childComplexity int) int {
+		return 1 + listComplexity*childComplexity
+	}
+	search := func(childComplexity int, _ string) int {
+		return list(childComplexity)
+	}
+
+	var c ComplexityRoot
+	c.Query.PopularMovies = func(childComplexity, _, size int) int {
+		return 1 + max(size, 1)*childComplexity
+	}
+	c.Query.SearchMovies = search
+	c.Query.SearchActors = search
+	c.Movie.Genres = list
+	c.Movie.Actors = list
+	c.Movie.Reviews = list
+	c.Movie.Ratings = list
+	c.Actor.Movies = list
+	c.User.Reviews = list
+	c.User.Ratings = list
+	return c
+}
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// depthLimit rejects operations nested deeper than its value before anything
+// is resolved, complexity alone lets a narrow but deep query through.
+type depthLimit int
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = depthLimit(0)
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	if depth := selectionDepth(op.SelectionSet); depth > int(d) {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+
+	return nil
+}
+
+func selectionDepth(selections ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if !strings.HasPrefix(s.Name, "__") {
+				depth = max(depth, 1+selectionDepth(s.SelectionSet))
+			}
+		case *ast.InlineFragment:
+			depth = max(depth, selectionDepth(s.SelectionSet))
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				depth = max(depth, selectionDepth(s.Definition.SelectionSet))
+			}
+		}
+	}
+	return depth
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/vikstrous/dataloadgen"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// Sibling fields are resolved concurrently and ask for their keys within
+	// microseconds of each other, so a short wait collects the whole level.
+	loaderWait = time.Millisecond
+	// loaderBatch keeps the IN lists of a single fetch reasonably short.
+	loaderBatch = 500
+	// usersConcurrency bounds the parallel requests to the auth service,
+	// which only looks up one user at a time.
+	usersConcurrency = 8
+)
+
+// loaders batch and deduplicate the lookups of a single request. They cache
+// for as long as the request lives and must not be shared between requests.
+type loaders struct {
+	movies       *dataloadgen.Loader[int, entities.Movie]
+	movieGenres  *dataloadgen.Loader[int, []entities.Genre]
+	movieActors  *dataloadgen.Loader[int, []entities.Actor]
+	movieReviews *dataloadgen.Loader[int, []entities.Review]
+	movieRatings *dataloadgen.Loader[int, []entities.Rating]
+	actorMovies  *dataloadgen.Loader[int, []int]
+	userReviews  *dataloadgen.Loader[int, []entities.Review]
+	userRatings  *dataloadgen.Loader[int, []entities.Rating]
+	users        *dataloadgen.Loader[int, entities.User]
+}
+
+func (r *Resolver) newLoaders() *loaders {
+	options := []dataloadgen.Option{
+		dataloadgen.WithWait(loaderWait),
+		dataloadgen.WithBatchCapacity(loaderBatch),
+		dataloadgen.WithTracer(otel.Tracer("gateway.graph")),
+	}
+
+	return &loaders{
+		movies:       dataloadgen.NewMappedLoader(r.fetchMovies, options...),
+		movieGenres:  dataloadgen.NewMappedLoader(grouped(r.movies.GetGenresByMovieIDs), options...),
+		movieActors:  dataloadgen.NewMappedLoader(grouped(r.movies.GetActorsByMovieIDs), options...),
+		movieReviews: dataloadgen.NewMappedLoader(grouped(r.reviews.GetByMovieIDs), options...),
+		movieRatings: dataloadgen.NewMappedLoader(grouped(r.ratings.GetByMovieIDs), options...),
+		actorMovies:  dataloadgen.NewMappedLoader(grouped(r.movies.GetIDsByActorIDs), options...),
+		userReviews:  dataloadgen.NewMappedLoader(grouped(r.reviews.GetByUserIDs), options...),
+		userRatings:  dataloadgen.NewMappedLoader(grouped(r.ratings.GetByUserIDs), options...),
+		users:        dataloadgen.NewLoader(r.fetchUsers, options...),
+	}
+}
+
+func (r *Resolver) fetchMovies(ctx context.Context, ids []int) (map[int]entities.Movie, error) {
+	movies, err := r.movies.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]entities.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+	return byID, nil
+}
+
+func (r *Resolver) fetchUsers(ctx context.Context, ids []int) ([]entities.User, []error) {
+	users := make([]entities.User, len(ids))
+	errs := make([]error, len(ids))
+
+	var group errgroup.Group
+	group.SetLimit(usersConcurrency)
+	for i, id := range ids {
+		group.Go(func() error {
+			users[i], errs[i] = r.auth.User(ctx, id)
+			return nil
+		})
+	}
+	group.Wait()
+
+	return users, errs
+}
+
+// grouped makes a parent without children load as an empty list rather than
+// as missing.
+func grouped[V any](fetch func(context.Context, []int) (map[int][]V, error)) func(context.Context, []int) (map[int][]V, error) {
+	return func(ctx context.Context, keys []int) (map[int][]V, error) {
+		values, err := fetch(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if _, ok := values[key]; !ok {
+				values[key] = []V{}
+			}
+		}
+		return values, nil
+	}
+}
+
+// loadMovie reports an id without a movie as repositories.ErrNotFound.
+func (l *loaders) loadMovie(ctx context.Context, id int) (entities.Movie, error) {
+	movie, err := l.movies.Load(ctx, id)
+	if errors.Is(err, dataloadgen.ErrNotFound) {
+		return entities.Movie{}, repositories.ErrNotFound
+	}
+	return movie, err
+}
+
+// loadMovies leaves out ids without a movie, they were deleted after the ids
+// were read.
+func (l *loaders) loadMovies(ctx context.Context, ids []int) ([]entities.Movie, error) {
+	movies, err := l.movies.LoadAll(ctx, ids)
+
+	var errs dataloadgen.ErrorSlice
+	if !errors.As(err, &errs) {
+		return movies, err
+	}
+
+	found := make([]entities.Movie, 0, len(movies))
+	for i, movie := range movies {
+		switch {
+		case errs[i] == nil:
+			found = append(found, movie)
+		case !errors.Is(errs[i], dataloadgen.ErrNotFound):
+			return nil, errs[i]
+		}
+	}
+	return found, nil
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+)
+
+// Resolver resolves the schema in api/schema.graphqls. Root queries go to the
+// repositories directly, everything nested goes through the loaders of the
+// request so a level of the graph costs one query however many parents it has.
+type Resolver struct {
+	movies  repositories.Movies
+	actors  repositories.Actors
+	reviews repositories.Reviews
+	ratings repositories.Ratings
+
+	auth clients.Auth
+}
+
+func NewResolver(movies repositories.Movies, actors repositories.Actors, reviews repositories.Reviews, ratings repositories.Ratings, auth clients.Auth) *Resolver {
+	return &Resolver{
+		movies:  movies,
+		actors:  actors,
+		reviews: reviews,
+		ratings: ratings,
+
+		auth: auth,
+	}
+}
+
+func (r *Resolver) Query() QueryResolver   { return queryResolver{r} }
+func (r *Resolver) Movie() MovieResolver   { return movieResolver{r} }
+func (r *Resolver) Actor() ActorResolver   { return actorResolver{r} }
+func (r *Resolver) Review() ReviewResolver { return reviewResolver{r} }
+func (r *Resolver) Rating() RatingResolver { return ratingResolver{r} }
+func (r *Resolver) User() UserResolver     { return userResolver{r} }
+
+type queryResolver struct{ *Resolver }
+
+func (q queryResolver) Movie(ctx context.Context, id int) (*entities.Movie, error) {
+	movie, err := loadersFromContext(ctx).loadMovie(ctx, id)
+	return nullIfNotFound(&movie, err)
+}
+
+func (q queryResolver) PopularMovies(ctx context.Context, page int, size int) ([]entities.Movie, error) {
+	if page < 1 || size < 1 {
+		return nil, invalidInput("page and size must be at least 1")
+	}
+
+	return q.movies.GetPopular(ctx, (page-1)*size, size)
+}
+
+func (q queryResolver) SearchMovies(ctx context.Context, prompt string) ([]entities.Movie, error) {
+	return q.movies.SearchByTitle(ctx, prompt)
+}
+
+func (q queryResolver) Actor(ctx context.Context, id int) (*entities.Actor, error) {
+	actor, err := q.actors.GetByID(ctx, id)
+	return nullIfNotFound(&actor, err)
+}
+
+func (q queryResolver) SearchActors(ctx context.Context, prompt string) ([]entities.Actor, error) {
+	return q.actors.SearchByName(ctx, prompt)
+}
+
+func (q queryResolver) User(ctx context.Context, id int) (*entities.User, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, id)
+	return nullIfNotFound(&user, err)
+}
+
+// nullIfNotFound resolves a nullable field for something that does not exist
+// to null, as the schema allows, instead of an error.
+func nullIfNotFound[T any](value *T, err error) (*T, error) {
+	if errors.Is(err, repositories.ErrNotFound) || errors.Is(err, clients.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/controllers"
+)
+
+type movieResolver struct{ *Resolver }
+
+func (m movieResolver) StreamLink(_ context.Context, obj *entities.Movie) (string, error) {
+	return controllers.AddStreamLink(*obj).StreamLink, nil
+}
+
+func (m movieResolver) TmdbVoteAverage(_ context.Context, obj *entities.Movie) (float64, error) {
+	return float64(obj.TheMovieDBVoteAverage), nil
+}
+
+func (m movieResolver) VoteAverage(_ context.Context, obj *entities.Movie) (float64, error) {
+	return float64(obj.VoteAverage), nil
+}
+
+// Genres and Actors reuse what the root query already preloaded, movies that
+// come from the loaders have neither.
+func (m movieResolver) Genres(ctx context.Context, obj *entities.Movie) ([]entities.Genre, error) {
+	if obj.Genres != nil {
+		return obj.Genres, nil
+	}
+
+	return loadersFromContext(ctx).movieGenres.Load(ctx, obj.ID)
+}
+
+func (m movieResolver) Actors(ctx context.Context, obj *entities.Movie) ([]entities.Actor, error) {
+	if obj.Actors != nil {
+		return obj.Actors, nil
+	}
+
+	return loadersFromContext(ctx).movieActors.Load(ctx, obj.ID)
+}
+
+func (m movieResolver) Reviews(ctx context.Context, obj *entities.Movie) ([]entities.Review, error) {
+	return loadersFromContext(ctx).movieReviews.Load(ctx, obj.ID)
+}
+
+func (m movieResolver) Ratings(ctx context.Context, obj *entities.Movie) ([]entities.Rating, error) {
+	return loadersFromContext(ctx).movieRatings.Load(ctx, obj.ID)
+}
+
+type actorResolver struct{ *Resolver }
+
+func (a actorResolver) Movies(ctx context.Context, obj *entities.Actor) ([]entities.Movie, error) {
+	l := loadersFromContext(ctx)
+
+	ids, err := l.actorMovies.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.loadMovies(ctx, ids)
+}
+
+type reviewResolver struct{ *Resolver }
+
+func (r reviewResolver) Movie(ctx context.Context, obj *entities.Review) (*entities.Movie, error) {
+	movie, err := loadersFromContext(ctx).loadMovie(ctx, obj.MovieID)
+	return &movie, err
+}
+
+func (r reviewResolver) User(ctx context.Context, obj *entities.Review) (*entities.User, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, obj.UserID)
+	return nullIfNotFound(&user, err)
+}
+
+type ratingResolver struct{ *Resolver }
+
+func (r ratingResolver) Rating(_ context.Context, obj *entities.Rating) (float64, error) {
+	return float64(obj.Rating), nil
+}
+
+func (r ratingResolver) Movie(ctx context.Context, obj *entities.Rating) (*entities.Movie, error) {
+	movie, err := loadersFromContext(ctx).loadMovie(ctx, obj.MovieID)
+	return &movie, err
+}
+
+func (r ratingResolver) User(ctx context.Context, obj *entities.Rating) (*entities.User, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, obj.UserID)
+	return nullIfNotFound(&user, err)
+}
+
+type userResolver struct{ *Resolver }
+
+func (u userResolver) Reviews(ctx context.Context, obj *entities.User) ([]entities.Review, error) {
+	return loadersFromContext(ctx).userReviews.Load(ctx, obj.ID)
+}
+
+func (u userResolver) Ratings(ctx context.Context, obj *entities.User) ([]entities.Rating, error) {
+	return loadersFromContext(ctx).userRatings.Load(ctx, obj.ID)
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// NewAdminOnly restricts /api/admin of every version to the configured user
+// ids. It has to run after NewAuth, which puts the user into the context.
+func NewAdminOnly(adminIDs []int) gin.HandlerFunc {
+	admins := make(map[int]struct{}, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(withoutVersion(c.Request.URL.Path), "/api/admin") {
+			c.Next()
+			return
+		}
+
+		user, err := UserFromContext(c.Request.Context())
+		if err != nil {
+			problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized, "")
+			return
+		}
+
+		if _, ok := admins[user.ID]; !ok {
+			problem.Abort(c, http.StatusForbidden, problem.CodeForbidden, "")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -8,30 +8,34 @@ import (
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
-	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
 	"github.com/gin-gonic/gin"
 )
 
 func NewAuth(auth clients.Auth) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if isUnauthorizableRequest(c) {
+			// Public routes still learn who is calling when a valid token is
+			// sent, an invalid one is ignored rather than rejected.
+			if header := c.Request.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+				if user, err := auth.Authorize(c.Request.Context(), header[7:]); err == nil {
+					c.Request = c.Request.WithContext(UserToContext(c.Request.Context(), user))
+				}
+			}
+
 			c.Next()
 			return
 		}
 
 		header := c.Request.Header.Get("Authorization")
 		if !strings.HasPrefix(header, "Bearer ") {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "invalid authorization header",
-			})
-			c.Abort()
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid authorization header")
 			return
 		}
 
 		user, err := auth.Authorize(c.Request.Context(), header[7:])
 		if err != nil {
-			sendError(c, err)
-			c.Abort()
+			problem.AbortWithError(c, err)
 			return
 		}
 
@@ -55,33 +59,13 @@ func UserFromContext(ctx context.Context) (entities.User, error) {
 	return *user, nil
 }
 
-func sendError(c *gin.Context, err error) {
-	statusCode := http.StatusInternalServerError
-
-	if errors.Is(err, repositories.ErrNotFound) || errors.Is(err, clients.ErrNotFound) {
-		statusCode = http.StatusNotFound
-		return
-	}
-
-	if errors.Is(err, repositories.ErrInvalidInput) || errors.Is(err, clients.ErrBadRequest) {
-		statusCode = http.StatusBadRequest
-		return
-	}
-
-	if errors.Is(err, clients.ErrUnauthorized) {
-		statusCode = http.StatusUnauthorized
-	}
-
-	c.JSON(statusCode, gin.H{
-		"error": err.Error(),
-	})
-}
-
 func isUnauthorizableRequest(c *gin.Context) bool {
-	path := c.Request.URL.Path
+	path := withoutVersion(c.Request.URL.Path)
 	return strings.HasPrefix(path, "/api/actors") ||
 		strings.HasPrefix(path, "/api/movies") ||
 		strings.HasPrefix(path, "/api/login") ||
+		strings.HasPrefix(path, "/api/refresh") ||
+		strings.HasPrefix(path, "/api/oidc") ||
 		strings.HasPrefix(path, "/api/register") ||
 		strings.HasPrefix(path, "/api/logout") ||
 		strings.HasPrefix(path, "/api/users") ||
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewCacheControl sets the Cache-Control header of GET responses. Policies are
+// looked up by "METHOD /full/path" like rate limits, the default applies to
+// every other route.
+func NewCacheControl(defaultPolicy string, routes map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		policy, ok := routes[c.Request.Method+" "+withoutVersion(c.FullPath())]
+		if !ok {
+			policy = defaultPolicy
+		}
+
+		if policy != "" {
+			c.Header("Cache-Control", policy)
+		}
+
+		c.Next()
+	}
+}
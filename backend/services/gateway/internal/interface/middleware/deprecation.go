@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation is announced with the Deprecation (RFC 9745) and Sunset
+// (RFC 8594) headers. Successor is the base path of the version that replaces
+// the operation and is linked with the same path.
+type Deprecation struct {
+	Since     time.Time
+	Sunset    time.Time
+	Successor string
+}
+
+// NewDeprecation announces the operations the spec marks as deprecated, their
+// dates and successor come from the x-deprecated-at, x-sunset and x-successor
+// extensions. A non-nil mount deprecates every other operation under basePath
+// as well, for versions that are going away as a whole.
+func NewDeprecation(spec *openapi3.T, basePath string, mount *Deprecation) (gin.HandlerFunc, error) {
+	routes := make(map[string]Deprecation)
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			route := method + " " + basePath + ginPath(path)
+
+			if !operation.Deprecated {
+				if mount != nil {
+					routes[route] = *mount
+				}
+				continue
+			}
+
+			deprecation, err := operationDeprecation(operation)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deprecation of %s %s: %w", method, path, err)
+			}
+			routes[route] = deprecation
+		}
+	}
+
+	return func(c *gin.Context) {
+		deprecation, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Header("Deprecation", "@"+strconv.FormatInt(deprecation.Since.Unix(), 10))
+		if !deprecation.Sunset.IsZero() {
+			c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if deprecation.Successor != "" {
+			successor := deprecation.Successor + strings.TrimPrefix(c.Request.URL.Path, basePath)
+			c.Writer.Header().Add("Link", "<"+successor+`>; rel="successor-version"`)
+		}
+
+		c.Next()
+	}, nil
+}
+
+func operationDeprecation(operation *openapi3.Operation) (Deprecation, error) {
+	var deprecation Deprecation
+
+	since, ok := operation.Extensions["x-deprecated-at"].(string)
+	if !ok {
+		return Deprecation{}, fmt.Errorf("x-deprecated-at is required")
+	}
+
+	var err error
+	if deprecation.Since, err = time.Parse(time.DateOnly, since); err != nil {
+		return Deprecation{}, fmt.Errorf("x-deprecated-at: %w", err)
+	}
+
+	if sunset, ok := operation.Extensions["x-sunset"].(string); ok {
+		if deprecation.Sunset, err = time.Parse(time.DateOnly, sunset); err != nil {
+			return Deprecation{}, fmt.Errorf("x-sunset: %w", err)
+		}
+	}
+
+	deprecation.Successor, _ = operation.Extensions["x-successor"].(string)
+	return deprecation, nil
+}
+
+// ginPath turns an OpenAPI path template into the gin route it is served as.
+func ginPath(path string) string {
+	return strings.NewReplacer("{", ":", "}", "").Replace(path)
+}
@@ -9,8 +9,26 @@ import (
 
 func NewMetric() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		done := metrics.StartRequest(c.Request.Context(), c.Request.Method, route)
+		defer done()
+
 		start := time.Now()
 		c.Next()
-		metrics.RecordResponseTime(c.Request.Context(), time.Since(start), c.FullPath())
+
+		metrics.RecordRequest(c.Request.Context(), metrics.HTTPRequest{
+			Method:       c.Request.Method,
+			Route:        route,
+			APIVersion:   apiVersion(route),
+			Deprecated:   c.Writer.Header().Get("Deprecation") != "",
+			Status:       c.Writer.Status(),
+			Duration:     time.Since(start),
+			RequestSize:  c.Request.ContentLength,
+			ResponseSize: int64(c.Writer.Size()),
+		})
 	}
 }
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+// NewOpenAPIValidator rejects requests that break the constraints of spec
+// before a handler sees them. With validateResponses every response is buffered
+// and one that breaks the spec is replaced with a 500, which is meant for tests
+// and staging where a drifting handler should fail loudly. Routes missing from
+// the spec are passed through.
+func NewOpenAPIValidator(spec *openapi3.T, basePath string, validateResponses bool) (gin.HandlerFunc, error) {
+	served := *spec
+	served.Servers = openapi3.Servers{{URL: basePath}}
+	router, err := gorillamux.NewRouter(&served)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build openapi router: %w", err)
+	}
+
+	// Authentication is left to NewAuth, the validator only checks shapes.
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidRequest, strings.Join(validationMessages(err, "request"), "; "))
+			return
+		}
+
+		if !validateResponses {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		output := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 writer.status,
+			Header:                 writer.Header(),
+			Options:                options,
+		}
+		output.SetBodyBytes(writer.body.Bytes())
+
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), output); err != nil {
+			ctxlogrus.Extract(c.Request.Context()).Errorf("response of %s %s breaks the openapi spec: %s",
+				c.Request.Method, route.Path, strings.Join(validationMessages(err, "response"), "; "))
+
+			for _, header := range []string{"Content-Length", "Content-Disposition", "ETag", "Last-Modified", "Cache-Control"} {
+				c.Writer.Header().Del(header)
+			}
+			problem.Abort(c, http.StatusInternalServerError, problem.CodeInternal, "")
+			return
+		}
+
+		writer.flush()
+	}, nil
+}
+
+// validationMessages flattens kin-openapi errors into one line per violation
+// without the schema dumps their Error methods include.
+func validationMessages(err error, subject string) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var messages []string
+		for _, inner := range e {
+			messages = append(messages, validationMessages(inner, subject)...)
+		}
+		return messages
+
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			subject = fmt.Sprintf("%s parameter %q", e.Parameter.In, e.Parameter.Name)
+		case e.RequestBody != nil:
+			subject = "body"
+		}
+
+		if e.Err == nil {
+			return []string{subject + ": " + e.Reason}
+		}
+		return validationMessages(e.Err, subject)
+
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			return []string{subject + ": " + e.Reason}
+		}
+		return validationMessages(e.Err, subject)
+
+	case *openapi3.SchemaError:
+		if path := e.JSONPointer(); len(path) > 0 {
+			subject += " field " + strings.Join(path, ".")
+		}
+		return []string{subject + ": " + e.Reason}
+
+	default:
+		return []string{subject + ": " + err.Error()}
+	}
+}
+
+// bufferedWriter holds the response back until it has been validated.
+type bufferedWriter struct {
+	gin.ResponseWriter
+
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+func (w *bufferedWriter) Flush() {}
+
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
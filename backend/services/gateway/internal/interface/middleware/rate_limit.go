@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/interface/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+)
+
+// NewRateLimit limits requests per user when the request is authenticated and
+// per client IP otherwise. Policies are looked up by "METHOD /full/path"
+// without the API version, the default policy applies to every other route. The
+// limiter fails open: if the store is unavailable the request is let through.
+func NewRateLimit(limits repositories.RateLimits, defaultPolicy entities.RateLimitPolicy, routes map[string]entities.RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + withoutVersion(c.FullPath())
+
+		policy, ok := routes[route]
+		if !ok {
+			policy = defaultPolicy
+		}
+
+		if policy.Limit <= 0 {
+			c.Next()
+			return
+		}
+
+		key := "ip:" + c.ClientIP()
+		if user, err := UserFromContext(c.Request.Context()); err == nil {
+			key = "user:" + strconv.Itoa(user.ID)
+		}
+
+		res, err := limits.Allow(c.Request.Context(), route+"|"+key, policy)
+		if err != nil {
+			ctxlogrus.Extract(c.Request.Context()).Warnf("rate limiter is unavailable: %s", err.Error())
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Period.Seconds())))
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			problem.Abort(c, http.StatusTooManyRequests, problem.CodeRateLimited, "")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NewClientRateLimit limits all the requests of a client IP under one budget.
+// It runs before authentication, so requests that end up rejected with 401 or
+// 400 are counted too and cannot be used to flood the auth service. Like
+// NewRateLimit it fails open.
+func NewClientRateLimit(limits repositories.RateLimits, policy entities.RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.Limit <= 0 {
+			c.Next()
+			return
+		}
+
+		res, err := limits.Allow(c.Request.Context(), "client|ip:"+c.ClientIP(), policy)
+		if err != nil {
+			ctxlogrus.Extract(c.Request.Context()).Warnf("rate limiter is unavailable: %s", err.Error())
+			c.Next()
+			return
+		}
+
+		// The RateLimit headers describe the route policy, which is checked
+		// later, this one only answers when the client is over its budget.
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			problem.Abort(c, http.StatusTooManyRequests, problem.CodeRateLimited, "")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NewLoginGuard locks out a username from a client IP after too many failed
+// logins. It only looks at POST /login and lets every other request through.
+func NewLoginGuard(limits repositories.RateLimits, policy entities.LockoutPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost || !strings.HasSuffix(c.FullPath(), "/login") || policy.MaxFailures <= 0 {
+			c.Next()
+			return
+		}
+
+		key := c.ClientIP() + "|" + basicUsername(c.Request.Header.Get("Authorization"))
+		logger := ctxlogrus.Extract(c.Request.Context())
+
+		lockedFor, err := limits.LockedFor(c.Request.Context(), key)
+		if err != nil {
+			logger.Warnf("unable to check login lockout: %s", err.Error())
+		}
+
+		if lockedFor > 0 {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(lockedFor)))
+			problem.Abort(c, http.StatusTooManyRequests, problem.CodeLoginLocked, "")
+			return
+		}
+
+		c.Next()
+
+		switch c.Writer.Status() {
+		case http.StatusOK:
+			if err := limits.ResetFailures(c.Request.Context(), key); err != nil {
+				logger.Warnf("unable to reset login failures: %s", err.Error())
+			}
+		case http.StatusUnauthorized:
+			if _, err := limits.RegisterFailure(c.Request.Context(), key, policy); err != nil {
+				logger.Warnf("unable to register login failure: %s", err.Error())
+			}
+		}
+	}
+}
+
+func basicUsername(header string) string {
+	if !strings.HasPrefix(header, "Basic ") {
+		return ""
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(header[6:])
+	if err != nil {
+		return ""
+	}
+
+	username, _, _ := strings.Cut(string(decoded), ":")
+	return username
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,449 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitsStub answers Allow with result and records what it was asked.
+type rateLimitsStub struct {
+	mu sync.Mutex
+
+	result   entities.RateLimitResult
+	err      error
+	keys     []string
+	policies []entities.RateLimitPolicy
+
+	lockedFor time.Duration
+	lockErr   error
+	checked   []string
+	failures  []string
+	resets    []string
+}
+
+var _ repositories.RateLimits = (*rateLimitsStub)(nil)
+
+func (s *rateLimitsStub) Allow(_ context.Context, key string, policy entities.RateLimitPolicy) (entities.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = append(s.keys, key)
+	s.policies = append(s.policies, policy)
+	return s.result, s.err
+}
+
+func (s *rateLimitsStub) LockedFor(_ context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checked = append(s.checked, key)
+	return s.lockedFor, s.lockErr
+}
+
+func (s *rateLimitsStub) RegisterFailure(_ context.Context, key string, _ entities.LockoutPolicy) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, key)
+	return 0, nil
+}
+
+func (s *rateLimitsStub) ResetFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resets = append(s.resets, key)
+	return nil
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve runs one request through router from a fixed client address.
+func serve(router *gin.Engine, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "192.0.2.1:4321"
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func rateLimitedRouter(limits repositories.RateLimits) *gin.Engine {
+	defaultPolicy := entities.RateLimitPolicy{Limit: 100, Period: time.Minute}
+	routes := map[string]entities.RateLimitPolicy{
+		"POST /api/login":     {Limit: 10, Period: time.Minute},
+		"GET /api/movies/:id": {},
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if c.GetHeader("X-Test-User") != "" {
+			c.Request = c.Request.WithContext(UserToContext(c.Request.Context(), entities.User{ID: 7}))
+		}
+	})
+	router.Use(NewRateLimit(limits, defaultPolicy, routes))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	for _, prefix := range []string{"/api", "/api/v1", "/api/v2"} {
+		router.POST(prefix+"/login", ok)
+		router.GET(prefix+"/movies/:id", ok)
+		router.GET(prefix+"/sessions", ok)
+	}
+
+	return router
+}
+
+func TestRateLimitPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		user      bool
+		wantKey   string
+		wantLimit int
+	}{
+		{
+			name:      "route policy",
+			method:    http.MethodPost,
+			path:      "/api/login",
+			wantKey:   "POST /api/login|ip:192.0.2.1",
+			wantLimit: 10,
+		},
+		{
+			name:      "versions share the route policy and budget",
+			method:    http.MethodPost,
+			path:      "/api/v2/login",
+			wantKey:   "POST /api/login|ip:192.0.2.1",
+			wantLimit: 10,
+		},
+		{
+			name:      "default policy",
+			method:    http.MethodGet,
+			path:      "/api/v1/sessions",
+			wantKey:   "GET /api/sessions|ip:192.0.2.1",
+			wantLimit: 100,
+		},
+		{
+			name:      "per user when authenticated",
+			method:    http.MethodGet,
+			path:      "/api/sessions",
+			user:      true,
+			wantKey:   "GET /api/sessions|user:7",
+			wantLimit: 100,
+		},
+		{
+			name:   "policy without a limit is not limited",
+			method: http.MethodGet,
+			path:   "/api/v1/movies/42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &rateLimitsStub{result: entities.RateLimitResult{Allowed: true}}
+
+			header := http.Header{}
+			if tt.user {
+				header.Set("X-Test-User", "1")
+			}
+
+			rec := serve(rateLimitedRouter(limits), tt.method, tt.path, header)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			if tt.wantKey == "" {
+				if len(limits.keys) != 0 {
+					t.Errorf("got limited as %v", limits.keys)
+				}
+				return
+			}
+
+			if len(limits.keys) != 1 || limits.keys[0] != tt.wantKey {
+				t.Errorf("got keys %v, want %q", limits.keys, tt.wantKey)
+			}
+			if len(limits.policies) == 1 && limits.policies[0].Limit != tt.wantLimit {
+				t.Errorf("got limit %d, want %d", limits.policies[0].Limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestRateLimitResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     entities.RateLimitResult
+		err        error
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{
+			name:       "allowed",
+			result:     entities.RateLimitResult{Allowed: true, Remaining: 9, ResetAfter: 5500 * time.Millisecond},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				"RateLimit-Policy":    "10;w=60",
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "6",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:       "denied",
+			result:     entities.RateLimitResult{RetryAfter: 1500 * time.Millisecond, ResetAfter: time.Minute},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeader: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "60",
+				"Retry-After":         "2",
+			},
+		},
+		{
+			name:       "fails open",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				"RateLimit-Limit": "",
+				"Retry-After":     "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &rateLimitsStub{result: tt.result, err: tt.err}
+
+			rec := serve(rateLimitedRouter(limits), http.MethodPost, "/api/login", nil)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			for name, want := range tt.wantHeader {
+				if got := rec.Header().Get(name); got != want {
+					t.Errorf("%s: got %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     entities.RateLimitPolicy
+		result     entities.RateLimitResult
+		err        error
+		wantStatus int
+		wantKeys   int
+	}{
+		{
+			name:       "rejected by auth is still counted",
+			policy:     entities.RateLimitPolicy{Limit: 10, Period: time.Minute},
+			result:     entities.RateLimitResult{Allowed: true},
+			wantStatus: http.StatusUnauthorized,
+			wantKeys:   1,
+		},
+		{
+			name:       "over the budget never reaches auth",
+			policy:     entities.RateLimitPolicy{Limit: 10, Period: time.Minute},
+			result:     entities.RateLimitResult{RetryAfter: time.Second},
+			wantStatus: http.StatusTooManyRequests,
+			wantKeys:   1,
+		},
+		{
+			name:       "fails open",
+			policy:     entities.RateLimitPolicy{Limit: 10, Period: time.Minute},
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusUnauthorized,
+			wantKeys:   1,
+		},
+		{
+			name:       "disabled",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &rateLimitsStub{result: tt.result, err: tt.err}
+
+			authReached := false
+			router := gin.New()
+			router.GET("/api/sessions", NewClientRateLimit(limits, tt.policy), func(c *gin.Context) {
+				authReached = true
+				c.AbortWithStatus(http.StatusUnauthorized)
+			})
+
+			rec := serve(router, http.MethodGet, "/api/sessions", nil)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if authReached == (tt.wantStatus == http.StatusTooManyRequests) {
+				t.Errorf("auth reached: %t with status %d", authReached, rec.Code)
+			}
+
+			if len(limits.keys) != tt.wantKeys {
+				t.Fatalf("got keys %v, want %d", limits.keys, tt.wantKeys)
+			}
+			for _, key := range limits.keys {
+				if key != "client|ip:192.0.2.1" {
+					t.Errorf("got key %q, want the client IP", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginGuard(t *testing.T) {
+	policy := entities.LockoutPolicy{MaxFailures: 5, Window: time.Minute, Duration: time.Minute}
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("alice:secret"))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		status        int
+		lockedFor     time.Duration
+		lockErr       error
+		wantStatus    int
+		wantChecked   bool
+		wantFailures  int
+		wantResets    int
+		wantRetryHint string
+	}{
+		{
+			name:        "successful login resets the failures",
+			method:      http.MethodPost,
+			path:        "/api/login",
+			status:      http.StatusOK,
+			wantStatus:  http.StatusOK,
+			wantChecked: true,
+			wantResets:  1,
+		},
+		{
+			name:         "wrong password is a failure",
+			method:       http.MethodPost,
+			path:         "/api/v1/login",
+			status:       http.StatusUnauthorized,
+			wantStatus:   http.StatusUnauthorized,
+			wantChecked:  true,
+			wantFailures: 1,
+		},
+		{
+			name:        "other errors are not counted",
+			method:      http.MethodPost,
+			path:        "/api/login",
+			status:      http.StatusBadRequest,
+			wantStatus:  http.StatusBadRequest,
+			wantChecked: true,
+		},
+		{
+			name:          "locked out",
+			method:        http.MethodPost,
+			path:          "/api/login",
+			status:        http.StatusOK,
+			lockedFor:     90 * time.Second,
+			wantStatus:    http.StatusTooManyRequests,
+			wantChecked:   true,
+			wantRetryHint: "90",
+		},
+		{
+			name:         "fails open",
+			method:       http.MethodPost,
+			path:         "/api/login",
+			status:       http.StatusUnauthorized,
+			lockErr:      errors.New("connection refused"),
+			wantStatus:   http.StatusUnauthorized,
+			wantChecked:  true,
+			wantFailures: 1,
+		},
+		{
+			name:       "other routes are not guarded",
+			method:     http.MethodPost,
+			path:       "/api/register",
+			status:     http.StatusUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &rateLimitsStub{lockedFor: tt.lockedFor, lockErr: tt.lockErr}
+
+			router := gin.New()
+			router.Use(NewLoginGuard(limits, policy))
+
+			reached := false
+			handler := func(c *gin.Context) {
+				reached = true
+				c.Status(tt.status)
+			}
+			router.POST("/api/login", handler)
+			router.POST("/api/v1/login", handler)
+			router.POST("/api/register", handler)
+
+			rec := serve(router, tt.method, tt.path, http.Header{"Authorization": {basic}})
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryHint {
+				t.Errorf("got Retry-After %q, want %q", got, tt.wantRetryHint)
+			}
+			if reached == (tt.lockedFor > 0) {
+				t.Errorf("handler reached: %t, locked for %s", reached, tt.lockedFor)
+			}
+
+			if got := len(limits.checked) > 0; got != tt.wantChecked {
+				t.Errorf("lockout checked: %t, want %t", got, tt.wantChecked)
+			}
+			for _, key := range limits.checked {
+				if key != "192.0.2.1|alice" {
+					t.Errorf("got key %q, want the client and username", key)
+				}
+			}
+			if len(limits.failures) != tt.wantFailures {
+				t.Errorf("got %d failures, want %d", len(limits.failures), tt.wantFailures)
+			}
+			if len(limits.resets) != tt.wantResets {
+				t.Errorf("got %d resets, want %d", len(limits.resets), tt.wantResets)
+			}
+		})
+	}
+}
+
+func TestBasicUsername(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Basic " + encode("alice:secret"), "alice"},
+		{"Basic " + encode("alice:with:colons"), "alice"},
+		{"Basic " + encode("alice"), "alice"},
+		{"Basic not base64", ""},
+		{"Bearer token", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := basicUsername(tt.header); got != tt.want {
+			t.Errorf("basicUsername(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
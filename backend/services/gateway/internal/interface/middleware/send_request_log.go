@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"context"
 	"time"
 
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/entities"
@@ -9,37 +8,36 @@ import (
 	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/infrastructure/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
-	"go.opentelemetry.io/otel/trace"
 )
 
-func NewSendRequestLog(requestLogs repositories.RequestLogs, tracer trace.Tracer) gin.HandlerFunc {
+// NewSendRequestLog writes a request log once the request is handled. The
+// repository is expected to queue the log rather than write it inline. It has
+// to wrap the handler, so it is registered on the router rather than as an API
+// middleware.
+func NewSendRequestLog(requestLogs repositories.RequestLogs) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		fullPath := c.FullPath()
 		c.Next()
 
-		user, _ := UserFromContext(c.Request.Context())
-		logger := ctxlogrus.Extract(c.Request.Context())
-		go func() {
-			ctx, span := tracer.Start(context.Background(), "request_log")
-			defer span.End()
+		ctx := c.Request.Context()
+		user, _ := UserFromContext(ctx)
 
-			requestLog := &entities.RequestLog{
-				TraceID:        tracing.GetTraceID(ctx),
-				Timestamp:      time.Now(),
-				Method:         c.Request.Method,
-				Path:           c.Request.URL.Path,
-				NormalizedPath: fullPath,
-				StatusCode:     c.Writer.Status(),
-				StatusClass:    getStatusClass(c.Writer.Status()),
-				DurationMs:     int(time.Since(start).Milliseconds()),
-				UserID:         user.ID,
-			}
+		requestLog := &entities.RequestLog{
+			TraceID:        tracing.GetTraceID(ctx),
+			Timestamp:      time.Now(),
+			Method:         c.Request.Method,
+			Path:           c.Request.URL.Path,
+			NormalizedPath: fullPath,
+			StatusCode:     c.Writer.Status(),
+			StatusClass:    getStatusClass(c.Writer.Status()),
+			DurationMs:     int(time.Since(start).Milliseconds()),
+			UserID:         user.ID,
+		}
 
-			if err := requestLogs.Insert(ctx, requestLog); err != nil {
-				logger.Warnf("unable to send request log to database %s", err.Error())
-			}
-		}()
+		if err := requestLogs.Insert(ctx, requestLog); err != nil {
+			ctxlogrus.Extract(ctx).Warnf("unable to send request log to database %s", err.Error())
+		}
 	}
 }
 
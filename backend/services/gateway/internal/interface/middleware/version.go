@@ -0,0 +1,49 @@
+package middleware
+
+import "strings"
+
+// Every API version is mounted as /api/<version>, the bare /api prefix serves
+// the first version to clients that predate versioning.
+const (
+	apiPrefix   = "/api/"
+	unversioned = "unversioned"
+)
+
+// apiVersion returns the version a route belongs to, "" outside of the API.
+func apiVersion(route string) string {
+	rest, ok := strings.CutPrefix(route, apiPrefix)
+	if !ok {
+		return ""
+	}
+
+	if segment, _, _ := strings.Cut(rest, "/"); isVersion(segment) {
+		return segment
+	}
+
+	return unversioned
+}
+
+// withoutVersion drops the version from an API path so that everything keyed
+// by path applies to all versions alike.
+func withoutVersion(path string) string {
+	rest, ok := strings.CutPrefix(path, apiPrefix)
+	if !ok {
+		return path
+	}
+
+	segment, tail, _ := strings.Cut(rest, "/")
+	if !isVersion(segment) {
+		return path
+	}
+
+	return apiPrefix + tail
+}
+
+func isVersion(segment string) bool {
+	digits, ok := strings.CutPrefix(segment, "v")
+	if !ok || digits == "" {
+		return false
+	}
+
+	return strings.Trim(digits, "0123456789") == ""
+}
@@ -0,0 +1,91 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/clients"
+	"github.com/allnighmatel0Ng/cinema/backend/services/gateway/internal/domain/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/improbable-eng/go-httpwares/logging/logrus/ctxlogrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ContentType = "application/problem+json"
+
+// Code is a stable machine readable error code, clients branch on it rather
+// than on the human readable fields.
+type Code string
+
+const (
+	CodeInvalidRequest Code = "invalid_request"
+	CodeInvalidInput   Code = "invalid_input"
+	CodeUnauthorized   Code = "unauthorized"
+	CodeForbidden      Code = "forbidden"
+	CodeNotFound       Code = "not_found"
+	CodeRateLimited    Code = "rate_limited"
+	CodeLoginLocked    Code = "login_locked"
+	CodeLoginRejected  Code = "login_rejected"
+	CodeInternal       Code = "internal"
+)
+
+var titles = map[Code]string{
+	CodeInvalidRequest: "Malformed request",
+	CodeInvalidInput:   "Invalid input",
+	CodeUnauthorized:   "Authentication required",
+	CodeForbidden:      "Access denied",
+	CodeNotFound:       "Resource not found",
+	CodeRateLimited:    "Too many requests",
+	CodeLoginLocked:    "Too many failed login attempts",
+	CodeLoginRejected:  "Login rejected",
+	CodeInternal:       "Internal error",
+}
+
+// Problem is an RFC 7807 problem details object extended with the code and
+// the trace id, which support can look the request up by.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Code     Code   `json:"code"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	TraceID  string `json:"trace_id,omitempty"`
+}
+
+// Abort writes the problem and stops the handler chain. Detail is shown to
+// the client as is and must not carry internal information.
+func Abort(c *gin.Context, status int, code Code, detail string) {
+	var traceID string
+	if span := trace.SpanContextFromContext(c.Request.Context()); span.HasTraceID() {
+		traceID = span.TraceID().String()
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(status, Problem{
+		Type:     "urn:cinema:problem:" + string(code),
+		Title:    titles[code],
+		Status:   status,
+		Code:     code,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		TraceID:  traceID,
+	})
+}
+
+// AbortWithError maps the domain errors of repositories and clients to
+// problems. Anything else is logged and answered with a bare internal error,
+// its message never reaches the client.
+func AbortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound), errors.Is(err, clients.ErrNotFound):
+		Abort(c, http.StatusNotFound, CodeNotFound, "")
+	case errors.Is(err, repositories.ErrInvalidInput), errors.Is(err, clients.ErrBadRequest):
+		Abort(c, http.StatusBadRequest, CodeInvalidInput, "")
+	case errors.Is(err, clients.ErrUnauthorized):
+		Abort(c, http.StatusUnauthorized, CodeUnauthorized, "")
+	default:
+		ctxlogrus.Extract(c.Request.Context()).Errorf("Internal error happened: %s", err.Error())
+		Abort(c, http.StatusInternalServerError, CodeInternal, "")
+	}
+}
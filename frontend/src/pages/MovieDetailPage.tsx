@@ -237,7 +237,10 @@ const MovieDetailPage: React.FC = () => {
 
               {movie.stream_link && (
                 <button
-                  onClick={() => setIsPlaying(true)}
+                  onClick={() => {
+                    setIsPlaying(true);
+                    apiService.recordStreamOpened(movie.id).catch(() => {});
+                  }}
                   className="inline-flex items-center px-6 py-3 bg-primary-600 text-white rounded-md hover:bg-primary-700 transition-colors"
                 >
                   <Play className="mr-2 h-5 w-5" />
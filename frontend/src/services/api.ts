@@ -2,7 +2,7 @@ import axios, { AxiosInstance } from 'axios';
 import { Movie, Actor, Review, User, AuthResponse } from '../types/api.ts';
 import {toast} from "react-hot-toast";
 
-const API_URL = 'http://158.160.167.40:8080/api';
+const API_URL = 'http://158.160.167.40:8080/api/v1';
 
 class ApiService {
   private api: AxiosInstance;
@@ -76,6 +76,10 @@ class ApiService {
     return response.data;
   }
 
+  public async recordStreamOpened(id: number): Promise<void> {
+    await this.api.post(`/movies/${id}/stream`);
+  }
+
   // Actor endpoints
   public async getActor(id: number): Promise<Actor> {
     const response = await this.api.get<Actor>(`/actors/${id}`);
@@ -171,6 +171,10 @@ class ApiService {
         );
     }
 
+    async recordStreamOpened(_id: number): Promise<void> {
+        return this.withLatency(() => undefined);
+    }
+
     /* ---------- ACTORS ---------- */
     async getActor(id: number): Promise<Actor> {
         return this.withLatency(() => {